@@ -0,0 +1,64 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"deepseek_golang_demo/services/actions"
+	"deepseek_golang_demo/services/analysis"
+	"deepseek_golang_demo/services/deepseek"
+	"deepseek_golang_demo/services/notification"
+)
+
+// runAnalyze 在命令行中分析记录，与 POST /api/analyze/{id} 使用同一个分析服务，
+// 逐条输出分析结果的 JSON：
+//
+//	go run . analyze [-tenant default] [-by cli] 1 2 3
+func runAnalyze(db *sql.DB, deepseekCli *deepseek.Client, args []string) error {
+	flags := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	tenantSlug := flags.String("tenant", "default", "记录所属的租户标识")
+	by := flags.String("by", "cli", "记录在分析结果中的操作者")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: analyze [-tenant slug] [-by name] <record id>...")
+	}
+
+	tenant, err := lookupTenant(db, *tenantSlug)
+	if err != nil {
+		return err
+	}
+	service := analysis.NewService(
+		analysis.NewRepository(db),
+		analysis.NewDeepSeekAnalyzer(deepseekCli),
+		actions.NewExecutor(db),
+		notification.NewDispatcher(db),
+	)
+
+	encoder := json.NewEncoder(os.Stdout)
+	var failed int
+	for _, arg := range flags.Args() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid record id %q", arg)
+		}
+		result, err := service.AnalyzeByID(tenant.ID, id, *by)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "record %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d records failed", failed, flags.NArg())
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) HandleListRecordRevisions(c *gin.Context) {
+	record, ok := s.loadRecord(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := models.ListRecordRevisions(s.db, record.TenantID, record.ID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing revisions: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (s *Server) HandleListRecordActions(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid record ID")
+		return
+	}
+
+	executions, err := s.listRecordActions(currentPrincipal(c), id)
+	if err != nil {
+		respondOpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, executions)
+}
+
+func (s *Server) HandleListRecordAnalyses(c *gin.Context) {
+	record, ok := s.loadRecord(c)
+	if !ok {
+		return
+	}
+
+	results, err := models.GetAnalysisResults(s.db, record.TenantID, record.ID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing analyses: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// HandleDiffRecordAnalyses 比较同一记录的两次分析结果，from/to 为分析结果ID，
+// 不指定时分别默认为倒数第二次和最近一次分析
+func (s *Server) HandleDiffRecordAnalyses(c *gin.Context) {
+	record, ok := s.loadRecord(c)
+	if !ok {
+		return
+	}
+
+	results, err := models.GetAnalysisResults(s.db, record.TenantID, record.ID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing analyses: %v", err))
+		return
+	}
+	if len(results) == 0 {
+		respondError(c, http.StatusNotFound, "Record has no analyses")
+		return
+	}
+
+	from, to := len(results)-2, len(results)-1
+	if from < 0 {
+		from = 0
+	}
+	if value := c.Query("from"); value != "" {
+		if from = indexOfAnalysis(results, value); from < 0 {
+			respondError(c, http.StatusNotFound, fmt.Sprintf("Analysis %s not found for this record", value))
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to = indexOfAnalysis(results, value); to < 0 {
+			respondError(c, http.StatusNotFound, fmt.Sprintf("Analysis %s not found for this record", value))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, models.DiffAnalyses(&results[from], &results[to]))
+}
+
+// indexOfAnalysis 返回指定ID的分析结果在列表中的位置，不存在时返回 -1
+func indexOfAnalysis(results []models.AnalysisResult, value string) int {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return -1
+	}
+	for i, result := range results {
+		if result.ID == id {
+			return i
+		}
+	}
+	return -1
+}
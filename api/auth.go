@@ -0,0 +1,116 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey gin 上下文中保存调用方的键
+const principalKey = "principal"
+
+// Principal 当前请求的调用方，租户由 API Key 决定
+type Principal struct {
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	TenantID int64  `json:"tenantId"`
+}
+
+// anonymousPrincipal AUTH_DISABLED=true 时所有请求使用的调用方
+var anonymousPrincipal = &Principal{Name: "anonymous", Role: models.RoleAdmin, TenantID: models.DefaultTenantID}
+
+// authenticate 校验 Authorization: Bearer <key> 或 X-API-Key 请求头中的 API Key
+func (s *Server) authenticate(c *gin.Context) {
+	if os.Getenv("AUTH_DISABLED") == "true" {
+		c.Set(principalKey, anonymousPrincipal)
+		c.Next()
+		return
+	}
+
+	key := c.GetHeader("X-API-Key")
+	if value := c.GetHeader("Authorization"); key == "" && value != "" {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			key = strings.TrimSpace(token)
+		}
+	}
+	if key == "" {
+		c.Header("WWW-Authenticate", `Bearer realm="api"`)
+		respondError(c, http.StatusUnauthorized, "API key required")
+		return
+	}
+
+	principal, err := s.principalForKey(key)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Error authenticating request")
+		return
+	}
+	if principal == nil {
+		c.Header("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+		respondError(c, http.StatusUnauthorized, "Invalid or revoked API key")
+		return
+	}
+
+	c.Set(principalKey, principal)
+	c.Next()
+}
+
+// principalForKey 校验 API Key 并返回对应的调用方，Key 无效或已吊销时返回 nil
+func (s *Server) principalForKey(key string) (*Principal, error) {
+	apiKey, err := models.AuthenticateAPIKey(s.db, key)
+	if err != nil {
+		log.Printf("校验API Key失败: %v", err)
+		return nil, err
+	}
+	if apiKey == nil {
+		return nil, nil
+	}
+	return &Principal{Name: apiKey.Name, Role: apiKey.Role, TenantID: apiKey.TenantID}, nil
+}
+
+// requireRole 要求调用方至少拥有 role 角色
+func requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := currentPrincipal(c)
+		if principal == nil || !models.RoleAllows(principal.Role, role) {
+			respondError(c, http.StatusForbidden, "Role "+role+" required")
+			return
+		}
+		c.Next()
+	}
+}
+
+// currentPrincipal 获取当前请求的调用方，未认证时返回 nil
+func currentPrincipal(c *gin.Context) *Principal {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil
+	}
+	principal, _ := value.(*Principal)
+	return principal
+}
+
+// principalName 获取当前请求调用方的名称，用于记录操作者
+func principalName(c *gin.Context) string {
+	if principal := currentPrincipal(c); principal != nil {
+		return principal.Name
+	}
+	return ""
+}
+
+// tenantID 获取当前请求调用方所属的租户
+func tenantID(c *gin.Context) int64 {
+	if principal := currentPrincipal(c); principal != nil {
+		return principal.TenantID
+	}
+	return 0
+}
+
+func (s *Server) HandleGetCurrentPrincipal(c *gin.Context) {
+	c.JSON(http.StatusOK, currentPrincipal(c))
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"deepseek_golang_demo/models"
+)
+
+// 自动分析方式
+const (
+	AutoAnalyzeOff   = "off"   // 不自动分析
+	AutoAnalyzeSync  = "sync"  // 在创建或修改请求中同步分析，响应包含分析结果ID
+	AutoAnalyzeAsync = "async" // 创建批量分析任务在后台分析，响应包含任务ID
+)
+
+// autoAnalysis 自动分析的执行情况，附加在记录的创建和修改响应中
+type autoAnalysis struct {
+	Mode       string `json:"mode"`
+	AnalysisID int64  `json:"analysisId,omitempty"`
+	BatchID    int64  `json:"batchId,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// recordResponse 记录及其自动分析情况
+type recordResponse struct {
+	*models.DataRecord
+	AutoAnalysis *autoAnalysis `json:"autoAnalysis,omitempty"`
+}
+
+// autoAnalyzeMode 读取 AUTO_ANALYZE 配置中数据类型的自动分析方式，
+// 格式为 "log=async,metrics=sync,*=off"，* 表示其他类型，未配置时不自动分析
+func autoAnalyzeMode(recordType string) string {
+	mode := AutoAnalyzeOff
+	for _, entry := range strings.Split(os.Getenv("AUTO_ANALYZE"), ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if value != AutoAnalyzeOff && value != AutoAnalyzeSync && value != AutoAnalyzeAsync {
+			log.Printf("忽略无效的AUTO_ANALYZE配置: %s", entry)
+			continue
+		}
+		if key == recordType {
+			return value
+		}
+		if key == "*" {
+			mode = value
+		}
+	}
+	return mode
+}
+
+// autoAnalyze 按数据类型的配置分析新建或内容已变化的记录，未开启自动分析时返回 nil。
+// 分析失败不影响记录的保存，错误信息记录在返回值中
+func (s *Server) autoAnalyze(record *models.DataRecord) *autoAnalysis {
+	mode := autoAnalyzeMode(record.Type)
+	switch mode {
+	case AutoAnalyzeSync:
+		analysis := &autoAnalysis{Mode: mode}
+		result, err := s.analysis.Analyze(record, record.UpdatedBy)
+		if err != nil {
+			analysis.Error = err.Error()
+		} else {
+			analysis.AnalysisID = result.ID
+		}
+		return analysis
+	case AutoAnalyzeAsync:
+		analysis := &autoAnalysis{Mode: mode}
+		batch, err := s.StartAnalysisBatch(record.TenantID, []int64{record.ID}, record.UpdatedBy)
+		if err != nil {
+			log.Printf("创建自动分析任务失败 (ID: %d): %v", record.ID, err)
+			analysis.Error = err.Error()
+		} else {
+			analysis.BatchID = batch.ID
+		}
+		return analysis
+	default:
+		return nil
+	}
+}
+
+// autoAnalyzeImported 为批量导入的记录创建后台分析任务。批量导入时 sync 也按 async 处理
+func (s *Server) autoAnalyzeImported(records []*models.DataRecord) {
+	var recordIDs []int64
+	var tenantID int64
+	var createdBy string
+	for _, record := range records {
+		tenantID = record.TenantID
+		createdBy = record.CreatedBy
+		if autoAnalyzeMode(record.Type) != AutoAnalyzeOff {
+			recordIDs = append(recordIDs, record.ID)
+		}
+	}
+	if len(recordIDs) == 0 {
+		return
+	}
+	if _, err := s.StartAnalysisBatch(tenantID, recordIDs, createdBy); err != nil {
+		log.Printf("创建自动分析任务失败 (记录数: %d): %v", len(recordIDs), err)
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultBatchMaxRecords 单个批量分析任务默认最多包含的记录数
+const defaultBatchMaxRecords = 1000
+
+// analysisBatchRequest 创建批量分析任务的请求体，ids 和 filter 二选一
+type analysisBatchRequest struct {
+	IDs    []int64                `json:"ids"`
+	Filter *models.RecordSelector `json:"filter"`
+	Limit  int                    `json:"limit"`
+}
+
+// batchMaxRecords 读取 ANALYZE_BATCH_MAX_RECORDS 配置
+func batchMaxRecords() int {
+	if value := os.Getenv("ANALYZE_BATCH_MAX_RECORDS"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+		log.Printf("忽略无效的ANALYZE_BATCH_MAX_RECORDS配置: %s", value)
+	}
+	return defaultBatchMaxRecords
+}
+
+// StartAnalysisBatch 以 createdBy 的身份为租户创建批量分析任务并在后台执行
+func (s *Server) StartAnalysisBatch(tenantID int64, recordIDs []int64, createdBy string) (*models.AnalysisBatch, error) {
+	batch, err := models.CreateAnalysisBatch(s.db, tenantID, recordIDs, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	s.batches.Start(batch.TenantID, batch.ID)
+	return batch, nil
+}
+
+func (s *Server) HandleCreateAnalysisBatch(c *gin.Context) {
+	var req analysisBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	batch, err := s.createAnalysisBatch(currentPrincipal(c), req.IDs, req.Filter, req.Limit)
+	if err != nil {
+		respondOpError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/analyze/batch/%d", batch.ID))
+	c.JSON(http.StatusAccepted, batch)
+}
+
+func (s *Server) HandleGetAnalysisBatch(c *gin.Context) {
+	id, ok := batchIDParam(c)
+	if !ok {
+		return
+	}
+
+	// 任务结束后附带汇总报告
+	batch, report, err := s.getAnalysisBatch(currentPrincipal(c), id)
+	if err != nil {
+		respondOpError(c, err)
+		return
+	}
+	response := gin.H{"batch": batch}
+	if report != nil {
+		response["report"] = report
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (s *Server) HandleCancelAnalysisBatch(c *gin.Context) {
+	id, ok := batchIDParam(c)
+	if !ok {
+		return
+	}
+
+	batch, err := s.cancelAnalysisBatch(currentPrincipal(c), id)
+	if err != nil {
+		respondOpError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, batch)
+}
+
+// batchIDParam 解析路径参数中的任务ID，失败时已写入错误响应
+func batchIDParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid batch ID")
+		return 0, false
+	}
+	return id, true
+}
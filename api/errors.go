@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 错误码，客户端根据错误码而不是错误信息判断错误类型
+const (
+	ErrCodeInvalidRequest       = "invalid_request"
+	ErrCodeValidationFailed     = "validation_failed"
+	ErrCodeUnauthorized         = "unauthorized"
+	ErrCodeForbidden            = "forbidden"
+	ErrCodeNotFound             = "not_found"
+	ErrCodeConflict             = "conflict"
+	ErrCodeGone                 = "gone"
+	ErrCodePreconditionFailed   = "precondition_failed"
+	ErrCodePreconditionRequired = "precondition_required"
+	ErrCodePayloadTooLarge      = "payload_too_large"
+	ErrCodeUnsupportedMedia     = "unsupported_media_type"
+	ErrCodeUnprocessable        = "unprocessable"
+	ErrCodeIdempotencyKeyReused = "idempotency_key_reused"
+	ErrCodeRateLimited          = "rate_limited"
+	ErrCodeBudgetExceeded       = "budget_exceeded"
+	ErrCodeInternal             = "internal_error"
+)
+
+// statusErrorCodes HTTP 状态码对应的默认错误码
+var statusErrorCodes = map[int]string{
+	http.StatusBadRequest:            ErrCodeInvalidRequest,
+	http.StatusUnauthorized:          ErrCodeUnauthorized,
+	http.StatusForbidden:             ErrCodeForbidden,
+	http.StatusNotFound:              ErrCodeNotFound,
+	http.StatusConflict:              ErrCodeConflict,
+	http.StatusGone:                  ErrCodeGone,
+	http.StatusPreconditionFailed:    ErrCodePreconditionFailed,
+	http.StatusPreconditionRequired:  ErrCodePreconditionRequired,
+	http.StatusRequestEntityTooLarge: ErrCodePayloadTooLarge,
+	http.StatusUnsupportedMediaType:  ErrCodeUnsupportedMedia,
+	http.StatusUnprocessableEntity:   ErrCodeUnprocessable,
+	http.StatusTooManyRequests:       ErrCodeRateLimited,
+}
+
+// apiError 统一的错误响应：{"error": {"code": "...", "message": "...", "details": [...]}}
+type apiError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []fieldError `json:"details,omitempty"`
+}
+
+// fieldError 请求校验失败的字段
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// respondError 返回错误响应并中止后续处理，错误码由状态码决定
+func respondError(c *gin.Context, status int, message string) {
+	code, ok := statusErrorCodes[status]
+	if !ok {
+		code = ErrCodeInternal
+	}
+	respondErrorCode(c, status, code, message)
+}
+
+// respondErrorCode 以指定的错误码返回错误响应并中止后续处理
+func respondErrorCode(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, gin.H{"error": apiError{Code: code, Message: message}})
+}
+
+// respondOpError 返回操作错误并中止后续处理，版本冲突时附带记录当前版本的 ETag
+func respondOpError(c *gin.Context, err error) {
+	e := asOpError(err)
+	if e.Version > 0 {
+		c.Header("ETag", fmt.Sprintf("%q", strconv.Itoa(e.Version)))
+	}
+	respondErrorCode(c, e.Status, e.errorCode(), e.Message)
+}
+
+// respondValidationError 返回请求校验失败的字段
+func respondValidationError(c *gin.Context, details []fieldError) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": apiError{
+		Code:    ErrCodeValidationFailed,
+		Message: "Request validation failed",
+		Details: details,
+	}})
+}
@@ -0,0 +1,451 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"deepseek_golang_demo/models"
+	pb "deepseek_golang_demo/proto/analysispb"
+	"deepseek_golang_demo/services/ratelimit"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// grpcErrorDomain gRPC 错误详情中 ErrorInfo 的 domain
+const grpcErrorDomain = "deepseek_golang_demo"
+
+// grpcWatchInterval WatchAnalysisBatch 查询任务进度的间隔
+const grpcWatchInterval = 2 * time.Second
+
+// grpcMethodRoles 各 gRPC 方法要求的最低角色，与对应的 HTTP 接口一致
+var grpcMethodRoles = map[string]string{
+	pb.DataAnalysis_CreateRecord_FullMethodName:        models.RoleAnalyst,
+	pb.DataAnalysis_GetRecord_FullMethodName:           models.RoleReader,
+	pb.DataAnalysis_ListRecords_FullMethodName:         models.RoleReader,
+	pb.DataAnalysis_UpdateRecord_FullMethodName:        models.RoleAnalyst,
+	pb.DataAnalysis_DeleteRecord_FullMethodName:        models.RoleAnalyst,
+	pb.DataAnalysis_RestoreRecord_FullMethodName:       models.RoleAnalyst,
+	pb.DataAnalysis_ListRecordActions_FullMethodName:   models.RoleReader,
+	pb.DataAnalysis_AnalyzeRecord_FullMethodName:       models.RoleAnalyst,
+	pb.DataAnalysis_AnalyzeRecords_FullMethodName:      models.RoleAnalyst,
+	pb.DataAnalysis_CreateAnalysisBatch_FullMethodName: models.RoleAnalyst,
+	pb.DataAnalysis_GetAnalysisBatch_FullMethodName:    models.RoleReader,
+	pb.DataAnalysis_WatchAnalysisBatch_FullMethodName:  models.RoleReader,
+	pb.DataAnalysis_CancelAnalysisBatch_FullMethodName: models.RoleAnalyst,
+}
+
+// grpcAnalyzeMethods 调用 DeepSeek 的方法，使用 analyze 限额。
+// AnalyzeRecords 在流中按记录逐条计数，不在这里计数
+var grpcAnalyzeMethods = map[string]bool{
+	pb.DataAnalysis_AnalyzeRecord_FullMethodName:       true,
+	pb.DataAnalysis_CreateAnalysisBatch_FullMethodName: true,
+}
+
+// grpcPrincipalKey context 中保存调用方的键
+type grpcPrincipalKey struct{}
+
+// grpcService 实现 gRPC 接口，只负责请求和响应的转换，业务逻辑与 HTTP 接口共用
+type grpcService struct {
+	pb.UnimplementedDataAnalysisServer
+	s *Server
+}
+
+// NewGRPCServer 创建提供数据分析 gRPC 接口的服务，认证、角色和限流规则与 HTTP 接口相同
+func (s *Server) NewGRPCServer() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.grpcUnaryInterceptor),
+		grpc.ChainStreamInterceptor(s.grpcStreamInterceptor),
+	)
+	pb.RegisterDataAnalysisServer(server, &grpcService{s: s})
+	// 支持 grpcurl 等工具查询接口定义
+	reflection.Register(server)
+	return server
+}
+
+func (s *Server) grpcUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.grpcAuthorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) grpcStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.grpcAuthorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &grpcAuthorizedStream{ServerStream: ss, ctx: ctx})
+}
+
+// grpcAuthorizedStream 携带调用方的流
+type grpcAuthorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *grpcAuthorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcAuthorize 校验 metadata 中的 API Key、调用方角色和限流，返回携带调用方的 context。
+// 反射服务等未在 grpcMethodRoles 中列出的方法只需认证
+func (s *Server) grpcAuthorize(ctx context.Context, method string) (context.Context, error) {
+	// 认证之前先按客户端 IP 限流，未认证和 API Key 无效的调用同样计入限额
+	if err := s.grpcRateLimit(ctx, nil, ratelimit.ClassAuth); err != nil {
+		return nil, err
+	}
+
+	principal, err := s.grpcAuthenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if role, ok := grpcMethodRoles[method]; ok && !models.RoleAllows(principal.Role, role) {
+		return nil, grpcError(newOpError(http.StatusForbidden, "Role %s required", role))
+	}
+
+	if method != pb.DataAnalysis_AnalyzeRecords_FullMethodName {
+		class := ratelimit.ClassAPI
+		if grpcAnalyzeMethods[method] {
+			class = ratelimit.ClassAnalyze
+		}
+		if err := s.grpcRateLimit(ctx, principal, class); err != nil {
+			return nil, err
+		}
+	}
+	return context.WithValue(ctx, grpcPrincipalKey{}, principal), nil
+}
+
+// grpcAuthenticate 校验 metadata 中 authorization: Bearer <key> 或 x-api-key 的 API Key
+func (s *Server) grpcAuthenticate(ctx context.Context) (*Principal, error) {
+	if os.Getenv("AUTH_DISABLED") == "true" {
+		return anonymousPrincipal, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var key string
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		key = values[0]
+	}
+	if values := md.Get("authorization"); key == "" && len(values) > 0 {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			key = strings.TrimSpace(token)
+		}
+	}
+	if key == "" {
+		return nil, grpcError(newOpError(http.StatusUnauthorized, "API key required"))
+	}
+
+	principal, err := s.principalForKey(key)
+	if err != nil {
+		return nil, grpcError(newOpError(http.StatusInternalServerError, "Error authenticating request"))
+	}
+	if principal == nil {
+		return nil, grpcError(newOpError(http.StatusUnauthorized, "Invalid or revoked API key"))
+	}
+	return principal, nil
+}
+
+// grpcRateLimit 按调用方计数，超出限额时返回 ResourceExhausted 并在 RetryInfo 中说明重试时间
+func (s *Server) grpcRateLimit(ctx context.Context, principal *Principal, class string) error {
+	var clientIP string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		clientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+
+	allowed, retryAfter := s.limiter.Allow(class, rateLimitKey(principal, clientIP))
+	if allowed {
+		return nil
+	}
+	st := status.New(codes.ResourceExhausted, "Too many requests")
+	if detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: ErrCodeRateLimited, Domain: grpcErrorDomain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// grpcPrincipal 获取当前调用的调用方
+func grpcPrincipal(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(grpcPrincipalKey{}).(*Principal)
+	return principal
+}
+
+// grpcStatusCodes HTTP 状态码对应的 gRPC 状态码
+var grpcStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.FailedPrecondition,
+	http.StatusGone:                  codes.NotFound,
+	http.StatusPreconditionFailed:    codes.Aborted,
+	http.StatusPreconditionRequired:  codes.FailedPrecondition,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+}
+
+// grpcError 将操作错误转换为 gRPC 状态，错误码放在 ErrorInfo 的 reason 中，
+// 版本冲突时 metadata 中的 version 为记录当前版本号
+func grpcError(err error) error {
+	e := asOpError(err)
+	code, ok := grpcStatusCodes[e.Status]
+	if !ok {
+		code = codes.Internal
+	}
+
+	info := &errdetails.ErrorInfo{Reason: e.errorCode(), Domain: grpcErrorDomain}
+	if e.Version > 0 {
+		info.Metadata = map[string]string{"version": strconv.Itoa(e.Version)}
+	}
+	st := status.New(code, e.Message)
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func (g *grpcService) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest) (*pb.RecordResponse, error) {
+	record := &models.DataRecord{Type: req.Type, Content: req.Content}
+	var err error
+	if record.Metadata, err = fromPBStruct(req.Metadata); err != nil {
+		return nil, grpcError(newOpError(http.StatusBadRequest, "Invalid metadata: %v", err))
+	}
+
+	response, err := g.s.createRecord(grpcPrincipal(ctx), record)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPBRecordResponse(response), nil
+}
+
+func (g *grpcService) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.Record, error) {
+	record, err := g.s.getRecord(grpcPrincipal(ctx).TenantID, req.Id, req.IncludeDeleted)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPBRecord(record), nil
+}
+
+func (g *grpcService) ListRecords(ctx context.Context, req *pb.ListRecordsRequest) (*pb.ListRecordsResponse, error) {
+	filter := fromPBFilter(req.Filter).RecordFilter(grpcPrincipal(ctx).TenantID)
+	filter.SortBy = req.Sort
+	filter.Order = req.Order
+	filter.Cursor = req.Cursor
+	filter.Limit = int(req.Limit)
+	filter.IncludeDeleted = req.IncludeDeleted
+	if err := filter.Normalize(); err != nil {
+		return nil, grpcError(newOpError(http.StatusBadRequest, "%v", err))
+	}
+
+	page, err := models.ListDataRecords(g.s.db, filter)
+	if err != nil {
+		return nil, grpcError(newOpError(http.StatusInternalServerError, "Error listing records: %v", err))
+	}
+	response := &pb.ListRecordsResponse{NextCursor: page.NextCursor}
+	for i := range page.Records {
+		response.Records = append(response.Records, toPBRecord(&page.Records[i]))
+	}
+	return response, nil
+}
+
+// UpdateRecord 与 HTTP 的 PATCH 相同，只修改提供的字段
+func (g *grpcService) UpdateRecord(ctx context.Context, req *pb.UpdateRecordRequest) (*pb.RecordResponse, error) {
+	principal := grpcPrincipal(ctx)
+	record, err := g.s.getWritableRecord(principal.TenantID, req.Id, int(req.ExpectedVersion))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	before := *record
+	if req.Type != nil {
+		if *req.Type == "" {
+			return nil, grpcError(newOpError(http.StatusBadRequest, "type must not be empty"))
+		}
+		record.Type = *req.Type
+	}
+	if req.Content != nil {
+		if *req.Content == "" {
+			return nil, grpcError(newOpError(http.StatusBadRequest, "content must not be empty"))
+		}
+		record.Content = *req.Content
+	}
+	if req.Metadata != nil {
+		if record.Metadata, err = fromPBStruct(req.Metadata); err != nil {
+			return nil, grpcError(newOpError(http.StatusBadRequest, "Invalid metadata: %v", err))
+		}
+	}
+
+	response, err := g.s.updateRecord(principal, &before, record)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPBRecordResponse(response), nil
+}
+
+func (g *grpcService) DeleteRecord(ctx context.Context, req *pb.DeleteRecordRequest) (*pb.DeleteRecordResponse, error) {
+	if err := g.s.deleteRecord(grpcPrincipal(ctx), req.Id, int(req.ExpectedVersion)); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.DeleteRecordResponse{}, nil
+}
+
+func (g *grpcService) RestoreRecord(ctx context.Context, req *pb.RestoreRecordRequest) (*pb.Record, error) {
+	record, err := g.s.restoreRecord(grpcPrincipal(ctx), req.Id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPBRecord(record), nil
+}
+
+func (g *grpcService) ListRecordActions(ctx context.Context, req *pb.ListRecordActionsRequest) (*pb.ListRecordActionsResponse, error) {
+	executions, err := g.s.listRecordActions(grpcPrincipal(ctx), req.RecordId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	response := &pb.ListRecordActionsResponse{}
+	for i := range executions {
+		response.Actions = append(response.Actions, toPBActionExecution(&executions[i]))
+	}
+	return response, nil
+}
+
+func (g *grpcService) AnalyzeRecord(ctx context.Context, req *pb.AnalyzeRecordRequest) (*pb.AnalysisResult, error) {
+	result, err := g.s.analyzeRecordByID(grpcPrincipal(ctx), req.RecordId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPBAnalysisResult(result), nil
+}
+
+// AnalyzeRecords 依次分析记录并逐条返回结果，单条记录失败不影响其他记录。
+// 每条记录消耗一次 analyze 限额，超出限额时结束流
+func (g *grpcService) AnalyzeRecords(req *pb.AnalyzeRecordsRequest, stream pb.DataAnalysis_AnalyzeRecordsServer) error {
+	ctx := stream.Context()
+	principal := grpcPrincipal(ctx)
+	if len(req.RecordIds) == 0 {
+		return grpcError(newOpError(http.StatusBadRequest, "No records to analyze"))
+	}
+	if maxRecords := batchMaxRecords(); len(req.RecordIds) > maxRecords {
+		return grpcError(newOpError(http.StatusBadRequest, "At most %d records may be analyzed at once", maxRecords))
+	}
+
+	for _, id := range req.RecordIds {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := g.s.grpcRateLimit(ctx, principal, ratelimit.ClassAnalyze); err != nil {
+			return err
+		}
+
+		event := &pb.AnalyzeRecordsEvent{RecordId: id}
+		if result, err := g.s.analyzeRecordByID(principal, id); err != nil {
+			e := asOpError(err)
+			event.Outcome = &pb.AnalyzeRecordsEvent_Error{Error: &pb.Error{Code: e.errorCode(), Message: e.Message}}
+		} else {
+			event.Outcome = &pb.AnalyzeRecordsEvent_Result{Result: toPBAnalysisResult(result)}
+		}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *grpcService) CreateAnalysisBatch(ctx context.Context, req *pb.CreateAnalysisBatchRequest) (*pb.AnalysisBatch, error) {
+	var selector *models.RecordSelector
+	if req.Filter != nil {
+		selector = fromPBFilter(req.Filter)
+	}
+	batch, err := g.s.createAnalysisBatch(grpcPrincipal(ctx), req.RecordIds, selector, int(req.Limit))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPBAnalysisBatch(batch), nil
+}
+
+func (g *grpcService) GetAnalysisBatch(ctx context.Context, req *pb.GetAnalysisBatchRequest) (*pb.AnalysisBatchStatus, error) {
+	batch, report, err := g.s.getAnalysisBatch(grpcPrincipal(ctx), req.Id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.AnalysisBatchStatus{Batch: toPBAnalysisBatch(batch), Report: toPBBatchReport(report)}, nil
+}
+
+// WatchAnalysisBatch 先返回任务当前状态，之后每次进度变化时返回最新状态，任务结束后结束流
+func (g *grpcService) WatchAnalysisBatch(req *pb.GetAnalysisBatchRequest, stream pb.DataAnalysis_WatchAnalysisBatchServer) error {
+	ctx := stream.Context()
+	principal := grpcPrincipal(ctx)
+	ticker := time.NewTicker(grpcWatchInterval)
+	defer ticker.Stop()
+
+	var last *models.AnalysisBatch
+	for {
+		batch, report, err := g.s.getAnalysisBatch(principal, req.Id)
+		if err != nil {
+			return grpcError(err)
+		}
+		if last == nil || batch.Status != last.Status || batch.Done != last.Done || batch.Failed != last.Failed {
+			event := &pb.AnalysisBatchStatus{Batch: toPBAnalysisBatch(batch), Report: toPBBatchReport(report)}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			last = batch
+		}
+		if report != nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (g *grpcService) CancelAnalysisBatch(ctx context.Context, req *pb.CancelAnalysisBatchRequest) (*pb.AnalysisBatch, error) {
+	batch, err := g.s.cancelAnalysisBatch(grpcPrincipal(ctx), req.Id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toPBAnalysisBatch(batch), nil
+}
+
+// fromPBFilter 将 gRPC 请求中的筛选条件转换为 RecordSelector，filter 为空时不筛选
+func fromPBFilter(filter *pb.RecordFilter) *models.RecordSelector {
+	selector := &models.RecordSelector{}
+	if filter == nil {
+		return selector
+	}
+	selector.Type = filter.Type
+	selector.Tags = filter.Tags
+	selector.Status = filter.Status
+	selector.Analyzed = filter.Analyzed
+	if len(filter.Metadata) > 0 {
+		selector.Metadata = filter.Metadata
+	}
+	selector.CreatedAfter = fromPBTime(filter.CreatedAfter)
+	selector.CreatedBefore = fromPBTime(filter.CreatedBefore)
+	selector.UpdatedAfter = fromPBTime(filter.UpdatedAfter)
+	selector.UpdatedBefore = fromPBTime(filter.UpdatedBefore)
+	return selector
+}
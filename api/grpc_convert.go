@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"deepseek_golang_demo/models"
+	pb "deepseek_golang_demo/proto/analysispb"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fromPBStruct 将 gRPC 请求中的 Struct 转换为 JSON 对象，为空时返回 nil
+func fromPBStruct(value *structpb.Struct) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := protojson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// toPBStruct 将 JSON 对象转换为 Struct，为空或不是对象时返回 nil
+func toPBStruct(data json.RawMessage) *structpb.Struct {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	value := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, value); err != nil {
+		log.Printf("转换元数据失败: %v", err)
+		return nil
+	}
+	return value
+}
+
+// fromPBTime 转换可选的时间，未设置时返回 nil
+func fromPBTime(value *timestamppb.Timestamp) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t := value.AsTime()
+	return &t
+}
+
+// toPBTime 转换可选的时间，为 nil 时返回 nil
+func toPBTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toPBRecord(record *models.DataRecord) *pb.Record {
+	return &pb.Record{
+		Id:        record.ID,
+		Type:      record.Type,
+		Content:   record.Content,
+		Metadata:  toPBStruct(record.Metadata),
+		Version:   int32(record.Version),
+		CreatedAt: timestamppb.New(record.CreatedAt),
+		UpdatedAt: timestamppb.New(record.UpdatedAt),
+		DeletedAt: toPBTime(record.DeletedAt),
+		CreatedBy: record.CreatedBy,
+		UpdatedBy: record.UpdatedBy,
+	}
+}
+
+func toPBRecordResponse(response *recordResponse) *pb.RecordResponse {
+	result := &pb.RecordResponse{Record: toPBRecord(response.DataRecord)}
+	if analysis := response.AutoAnalysis; analysis != nil {
+		result.AutoAnalysis = &pb.AutoAnalysis{
+			Mode:       analysis.Mode,
+			AnalysisId: analysis.AnalysisID,
+			BatchId:    analysis.BatchID,
+			Error:      analysis.Error,
+		}
+	}
+	return result
+}
+
+func toPBAnalysisResult(result *models.AnalysisResult) *pb.AnalysisResult {
+	return &pb.AnalysisResult{
+		Id:          result.ID,
+		RecordId:    result.RecordID,
+		RevisionId:  result.RevisionID,
+		Revision:    int32(result.Revision),
+		Analysis:    result.Analysis,
+		Suggestions: result.Suggestions,
+		Confidence:  result.Confidence,
+		Severity:    int32(result.Severity),
+		CreatedBy:   result.CreatedBy,
+		CreatedAt:   timestamppb.New(result.CreatedAt),
+	}
+}
+
+func toPBActionExecution(execution *models.ActionExecution) *pb.ActionExecution {
+	result := &pb.ActionExecution{
+		Id:         execution.ID,
+		RecordId:   execution.RecordID,
+		AnalysisId: execution.AnalysisID,
+		Type:       execution.Type,
+		Target:     execution.Target,
+		Status:     execution.Status,
+		Error:      execution.Error,
+		ExecutedBy: execution.ExecutedBy,
+		CreatedAt:  timestamppb.New(execution.CreatedAt),
+	}
+	if len(execution.Params) > 0 {
+		params, err := structpb.NewStruct(execution.Params)
+		if err != nil {
+			log.Printf("转换操作参数失败 (ID: %d): %v", execution.ID, err)
+		}
+		result.Params = params
+	}
+	return result
+}
+
+func toPBAnalysisBatch(batch *models.AnalysisBatch) *pb.AnalysisBatch {
+	return &pb.AnalysisBatch{
+		Id:         batch.ID,
+		Status:     batch.Status,
+		Total:      int32(batch.Total),
+		Done:       int32(batch.Done),
+		Failed:     int32(batch.Failed),
+		Remaining:  int32(batch.Remaining),
+		CreatedBy:  batch.CreatedBy,
+		CreatedAt:  timestamppb.New(batch.CreatedAt),
+		StartedAt:  toPBTime(batch.StartedAt),
+		FinishedAt: toPBTime(batch.FinishedAt),
+	}
+}
+
+// toPBBatchReport 转换批量分析任务的汇总报告，任务未结束时为 nil
+func toPBBatchReport(report *models.AnalysisBatchReport) *pb.AnalysisBatchReport {
+	if report == nil {
+		return nil
+	}
+	result := &pb.AnalysisBatchReport{
+		Succeeded:         int32(report.Succeeded),
+		Failed:            int32(report.Failed),
+		AverageConfidence: report.AverageConfidence,
+		SeverityCounts:    make(map[int32]int32, len(report.SeverityCounts)),
+	}
+	for severity, count := range report.SeverityCounts {
+		result.SeverityCounts[int32(severity)] = int32(count)
+	}
+	for _, item := range report.Failures {
+		result.Failures = append(result.Failures, &pb.AnalysisBatchItem{
+			RecordId:  item.RecordID,
+			Status:    item.Status,
+			ResultId:  item.ResultID,
+			Error:     item.Error,
+			UpdatedAt: timestamppb.New(item.UpdatedAt),
+		})
+	}
+	return result
+}
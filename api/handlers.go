@@ -6,119 +6,253 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"deepseek_golang_demo/models"
 	"deepseek_golang_demo/services/actions"
+	"deepseek_golang_demo/services/analysis"
+	"deepseek_golang_demo/services/batch"
 	"deepseek_golang_demo/services/deepseek"
+	"deepseek_golang_demo/services/notification"
+	"deepseek_golang_demo/services/ratelimit"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
-	db          *sql.DB
-	deepseekCli *deepseek.Client
+	db       *sql.DB
+	analysis *analysis.Service
+	batches  *batch.Runner
+	limiter  *ratelimit.Limiter
+	openAPI  *openAPI
 }
 
 func NewServer(db *sql.DB, deepseekCli *deepseek.Client) *Server {
+	analysisService := analysis.NewService(
+		analysis.NewRepository(db),
+		analysis.NewDeepSeekAnalyzer(deepseekCli),
+		actions.NewExecutor(db),
+		notification.NewDispatcher(db),
+	)
 	return &Server{
-		db:          db,
-		deepseekCli: deepseekCli,
+		db:       db,
+		analysis: analysisService,
+		batches:  batch.NewRunner(db, analysisService.Analyze),
+		limiter:  ratelimit.NewFromEnv(db),
+		openAPI:  mustLoadOpenAPI(),
+	}
+}
+
+// ResumeBatches 继续执行服务重启前未完成的批量分析任务
+func (s *Server) ResumeBatches() error {
+	return s.batches.Resume()
+}
+
+// RunBatchRecovery 定期接管心跳超时的实例上未完成的批量分析任务
+func (s *Server) RunBatchRecovery(interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := s.batches.Resume(); err != nil {
+			log.Printf("接管批量分析任务失败: %v", err)
+		}
 	}
 }
 
 func (s *Server) SetupRoutes(r *gin.Engine) {
-	api := r.Group("/api")
-	api.POST("/analyze/:id", s.HandleAnalyzeData)
-	api.POST("/records", s.HandleCreateRecord)
-	api.GET("/records/:id", s.HandleGetRecord)
+	r.NoRoute(func(c *gin.Context) {
+		respondError(c, http.StatusNotFound, "Route not found")
+	})
+
+	// 接口文档
+	r.GET("/openapi.json", s.HandleOpenAPI)
+	r.GET("/docs", s.HandleSwaggerUI)
+
+	// 签名的确认链接自带校验，无需 API Key
+	r.GET("/api/notifications/:id/ack", s.rateLimit, s.HandleAckNotificationLink)
+
+	api := r.Group("/api", s.rateLimitIP, s.authenticate, s.rateLimit, s.validateRequest)
+	reader := api.Group("", requireRole(models.RoleReader))
+	analyst := api.Group("", requireRole(models.RoleAnalyst))
+	approver := api.Group("", requireRole(models.RoleApprover))
+	admin := api.Group("", requireRole(models.RoleAdmin))
+
+	reader.GET("/me", s.HandleGetCurrentPrincipal)
+	reader.GET("/tenant", s.HandleGetTenant)
+	admin.PUT("/tenant", s.HandleUpdateTenant)
+
+	analyst.POST("/analyze/:id", s.idempotent, s.HandleAnalyzeData)
+	analyst.POST("/analyze/batch", s.HandleCreateAnalysisBatch)
+	reader.GET("/analyze/batch/:id", s.HandleGetAnalysisBatch)
+	analyst.POST("/analyze/batch/:id/cancel", s.HandleCancelAnalysisBatch)
+	reader.GET("/records", s.HandleListRecords)
+	analyst.POST("/records", s.idempotent, s.HandleCreateRecord)
+	analyst.POST("/records/bulk", s.HandleBulkImportRecords)
+	reader.GET("/records/:id", s.HandleGetRecord)
+	analyst.PUT("/records/:id", s.HandleUpdateRecord)
+	analyst.PATCH("/records/:id", s.HandlePatchRecord)
+	analyst.DELETE("/records/:id", s.HandleDeleteRecord)
+	analyst.POST("/records/:id/restore", s.HandleRestoreRecord)
+	reader.GET("/records/:id/revisions", s.HandleListRecordRevisions)
+	reader.GET("/records/:id/analyses", s.HandleListRecordAnalyses)
+	reader.GET("/records/:id/analyses/diff", s.HandleDiffRecordAnalyses)
+	reader.GET("/records/:id/actions", s.HandleListRecordActions)
+	reader.GET("/records/:id/tags", s.HandleListRecordTags)
+	analyst.POST("/records/:id/tags", s.HandleAddRecordTag)
+	analyst.DELETE("/records/:id/tags/:tag", s.HandleDeleteRecordTag)
+
+	reader.GET("/tags", s.HandleListTags)
+	approver.POST("/tags/merge", s.HandleMergeTags)
+	reader.GET("/tags/aliases", s.HandleListTagAliases)
+	approver.POST("/tags/aliases", s.HandleCreateTagAlias)
+	approver.DELETE("/tags/aliases/:alias", s.HandleDeleteTagAlias)
+	reader.GET("/tags/vocabulary", s.HandleListVocabulary)
+	approver.POST("/tags/vocabulary", s.HandleAddVocabularyTag)
+	approver.DELETE("/tags/vocabulary/:name", s.HandleDeleteVocabularyTag)
+
+	reader.GET("/notification-groups", s.HandleListNotificationGroups)
+	admin.POST("/notification-groups", s.HandleCreateNotificationGroup)
+	reader.GET("/notification-groups/:id", s.HandleGetNotificationGroup)
+	admin.PUT("/notification-groups/:id", s.HandleUpdateNotificationGroup)
+	admin.DELETE("/notification-groups/:id", s.HandleDeleteNotificationGroup)
+
+	reader.GET("/notification-routes", s.HandleListNotificationRoutes)
+	admin.POST("/notification-routes", s.HandleCreateNotificationRoute)
+	admin.PUT("/notification-routes/:id", s.HandleUpdateNotificationRoute)
+	admin.DELETE("/notification-routes/:id", s.HandleDeleteNotificationRoute)
+
+	reader.GET("/notifications/:id", s.HandleGetNotification)
+	approver.POST("/notifications/:id/ack", s.HandleAckNotification)
+
+	reader.GET("/schedules", s.HandleListSchedules)
+	approver.POST("/schedules", s.HandleCreateSchedule)
+	reader.GET("/schedules/:id", s.HandleGetSchedule)
+	approver.PUT("/schedules/:id", s.HandleUpdateSchedule)
+	approver.DELETE("/schedules/:id", s.HandleDeleteSchedule)
+	approver.POST("/schedules/:id/run", s.HandleRunSchedule)
+
+	reader.GET("/escalation-policies", s.HandleListEscalationPolicies)
+	admin.POST("/escalation-policies", s.HandleCreateEscalationPolicy)
+	admin.PUT("/escalation-policies/:id", s.HandleUpdateEscalationPolicy)
+	admin.DELETE("/escalation-policies/:id", s.HandleDeleteEscalationPolicy)
 }
 
 func (s *Server) HandleAnalyzeData(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Printf("无效的记录ID: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的记录ID"})
+		respondError(c, http.StatusBadRequest, "Invalid record ID")
 		return
 	}
 
-	record, err := models.GetDataRecord(s.db, id)
+	result, err := s.analyzeRecordByID(currentPrincipal(c), id)
 	if err != nil {
-		log.Printf("获取记录失败 (ID: %d): %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("获取记录失败: %v", err)})
+		respondOpError(c, err)
 		return
 	}
-	if record == nil {
-		log.Printf("记录未找到 (ID: %d)", id)
-		c.JSON(http.StatusNotFound, gin.H{"error": "记录未找到"})
+	c.JSON(http.StatusOK, result)
+}
+
+func (s *Server) HandleCreateRecord(c *gin.Context) {
+	var record models.DataRecord
+	if err := c.ShouldBindJSON(&record); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// 构建分析提示词
-	prompt := fmt.Sprintf("请分析以下%s类型的数据：\n%s", record.Type, record.Content)
-
-	// 调用DeepSeek API进行分析
-	response, err := s.deepseekCli.AnalyzeData(prompt, record)
+	response, err := s.createRecord(currentPrincipal(c), &record)
 	if err != nil {
-		log.Printf("数据分析失败 (ID: %d): %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("数据分析失败: %v", err)})
+		respondOpError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, response)
+}
 
-	// 保存分析结果
-	result := &models.AnalysisResult{
-		RecordID:    id,
-		Analysis:    response.Analysis,
-		Suggestions: response.Suggestions,
-		Confidence:  response.Confidence,
+func (s *Server) HandleListRecords(c *gin.Context) {
+	filter := models.RecordFilter{
+		TenantID: tenantID(c),
+		Type:     c.Query("type"),
+		Tags:     c.QueryArray("tag"),
+		Status:   c.Query("status"),
+		SortBy:   c.Query("sort"),
+		Order:    c.Query("order"),
+		Cursor:   c.Query("cursor"),
+
+		IncludeDeleted: c.Query("include_deleted") == "true",
 	}
 
-	if err := models.SaveAnalysisResult(s.db, result); err != nil {
-		log.Printf("保存分析结果失败 (ID: %d): %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("保存分析结果失败: %v", err)})
-		return
+	// meta.<path>=value 按元数据字段过滤
+	for key, values := range c.Request.URL.Query() {
+		if path, ok := strings.CutPrefix(key, "meta."); ok && len(values) > 0 {
+			if filter.Metadata == nil {
+				filter.Metadata = map[string]string{}
+			}
+			filter.Metadata[path] = values[0]
+		}
 	}
 
-	// 执行建议的操作
-	for i, action := range response.Actions {
-		if err := actions.ExecuteAction(action, s.db); err != nil {
-			log.Printf("执行操作失败 (ID: %d, 操作索引: %d, 类型: %s): %v", id, i, action.Type, err)
+	var err error
+	if value := c.Query("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid limit")
+			return
 		}
 	}
 
-	c.JSON(http.StatusOK, result)
-}
+	timeParams := map[string]**time.Time{
+		"created_after":  &filter.CreatedAfter,
+		"created_before": &filter.CreatedBefore,
+		"updated_after":  &filter.UpdatedAfter,
+		"updated_before": &filter.UpdatedBefore,
+	}
+	for name, target := range timeParams {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid %s, expected RFC3339 time", name))
+			return
+		}
+		*target = &t
+	}
 
-func (s *Server) HandleCreateRecord(c *gin.Context) {
-	var record models.DataRecord
-	if err := c.ShouldBindJSON(&record); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	if value := c.Query("analyzed"); value != "" {
+		analyzed, err := strconv.ParseBool(value)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid analyzed, expected true or false")
+			return
+		}
+		filter.Analyzed = &analyzed
+	}
+
+	if err := filter.Normalize(); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if err := models.CreateDataRecord(s.db, &record); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Error creating record: %v", err)})
+	page, err := models.ListDataRecords(s.db, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing records: %v", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, record)
+	c.JSON(http.StatusOK, page)
 }
 
 func (s *Server) HandleGetRecord(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid record ID"})
+		respondError(c, http.StatusBadRequest, "Invalid record ID")
 		return
 	}
 
-	record, err := models.GetDataRecord(s.db, id)
+	record, err := s.getRecord(tenantID(c), id, c.Query("include_deleted") == "true")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Error getting record: %v", err)})
-		return
-	}
-	if record == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+		respondOpError(c, err)
 		return
 	}
 
+	setRecordETag(c, record)
 	c.JSON(http.StatusOK, record)
 }
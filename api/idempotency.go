@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultIdempotencyTTL 未配置 IDEMPOTENCY_TTL 时幂等键的保留时间
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// idempotencyStaleAfter 超过该时间仍未完成的请求视为已中断，允许使用相同幂等键重试
+	idempotencyStaleAfter = 10 * time.Minute
+	// maxIdempotencyKeyLength 幂等键的最大长度
+	maxIdempotencyKeyLength = 191
+)
+
+// idempotencyTTL 读取 IDEMPOTENCY_TTL 配置的幂等键保留时间
+func idempotencyTTL() time.Duration {
+	value := os.Getenv("IDEMPOTENCY_TTL")
+	if value == "" {
+		return DefaultIdempotencyTTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Printf("忽略无效的IDEMPOTENCY_TTL配置: %s", value)
+		return DefaultIdempotencyTTL
+	}
+	return ttl
+}
+
+// RunIdempotencyCleanup 定期删除过期的幂等键，阻塞直到进程退出
+func (s *Server) RunIdempotencyCleanup(interval time.Duration) {
+	for range time.Tick(interval) {
+		if _, err := models.DeleteExpiredIdempotencyKeys(s.db); err != nil {
+			log.Printf("清理过期幂等键失败: %v", err)
+		}
+	}
+}
+
+// responseRecorder 在写出响应的同时保存响应体
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// idempotent 支持 Idempotency-Key 请求头：同一调用方使用相同幂等键重试时直接返回首次请求的响应，
+// 不会重复创建记录或重复调用 DeepSeek。幂等键对应的请求体不同时返回 422，首次请求仍在处理时返回 409。
+// 服务端错误（5xx）和 429 不保存，客户端可以使用相同幂等键重试
+func (s *Server) idempotent(c *gin.Context) {
+	key := c.GetHeader("Idempotency-Key")
+	if key == "" {
+		c.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		respondError(c, http.StatusBadRequest,
+			fmt.Sprintf("Idempotency-Key must not exceed %d characters", maxIdempotencyKeyLength))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Error reading request body")
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", c.Request.Method, c.Request.URL.Path)
+	hash.Write(body)
+	requestHash := hex.EncodeToString(hash.Sum(nil))
+
+	principal := currentPrincipal(c)
+	scope := fmt.Sprintf("%d:%s", principal.TenantID, principal.Name)
+	now := time.Now()
+	existing, err := models.ReserveIdempotencyKey(s.db, scope, key, requestHash,
+		now.Add(idempotencyTTL()), now.Add(-idempotencyStaleAfter))
+	if err != nil {
+		log.Printf("占用幂等键失败 (%s): %v", key, err)
+		respondError(c, http.StatusInternalServerError, "Error checking idempotency key")
+		return
+	}
+	if existing != nil {
+		switch {
+		case existing.RequestHash != requestHash:
+			respondErrorCode(c, http.StatusUnprocessableEntity, ErrCodeIdempotencyKeyReused,
+				"Idempotency-Key has already been used with a different request")
+		case existing.StatusCode == 0:
+			respondError(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+		default:
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+			c.Abort()
+		}
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	if status := recorder.Status(); status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+		if err := models.ReleaseIdempotencyKey(s.db, scope, key); err != nil {
+			log.Printf("释放幂等键失败 (%s): %v", key, err)
+		}
+	} else if err := models.CompleteIdempotencyKey(s.db, scope, key, status,
+		recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+		log.Printf("保存幂等键响应失败 (%s): %v", key, err)
+	}
+}
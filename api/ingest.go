@@ -0,0 +1,144 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"time"
+
+	"deepseek_golang_demo/services/ingest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bulkImportResponse 批量导入的汇总结果
+type bulkImportResponse struct {
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Files    []*ingest.Result `json:"files"`
+}
+
+// HandleBulkImportRecords 批量导入记录：请求体为 NDJSON 或 CSV，或以 multipart/form-data 上传多个文件
+func (s *Server) HandleBulkImportRecords(c *gin.Context) {
+	options := ingest.Options{
+		Type:       c.Query("type"),
+		Split:      c.Query("split"),
+		TenantID:   tenantID(c),
+		CreatedBy:  principalName(c),
+		OnImported: s.autoAnalyzeImported,
+	}
+	if value := c.Query("window"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			respondError(c, http.StatusBadRequest, "Invalid window")
+			return
+		}
+		options.Window = window
+	}
+	if !ingest.ValidSplit(options.Split) {
+		respondValidationError(c, []fieldError{{Field: "split", Message: "must be one of none, incident, window"}})
+		return
+	}
+	if format := c.Query("format"); format != "" && !ingest.ValidFormat(format) {
+		respondValidationError(c, []fieldError{{Field: "format", Message: "must be one of ndjson, csv, log"}})
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid Content-Type")
+		return
+	}
+
+	response := &bulkImportResponse{Files: []*ingest.Result{}}
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		options.Format = ingest.FormatNDJSON
+		err = s.importStream(c, response, options)
+	case "text/csv":
+		options.Format = ingest.FormatCSV
+		err = s.importStream(c, response, options)
+	case "text/plain":
+		options.Format = ingest.FormatLog
+		err = s.importStream(c, response, options)
+	case "multipart/form-data":
+		err = s.importFiles(c, response, options)
+	default:
+		respondError(c, http.StatusUnsupportedMediaType, fmt.Sprintf("Unsupported Content-Type: %s", mediaType))
+		return
+	}
+	if err != nil {
+		// 请求体无法解析时返回 400，其他错误（如数据库错误）返回 500
+		status, code := http.StatusInternalServerError, ErrCodeInternal
+		var inputErr *ingest.InputError
+		if errors.As(err, &inputErr) {
+			status, code = http.StatusBadRequest, ErrCodeValidationFailed
+		} else {
+			log.Printf("批量导入失败: %v", err)
+		}
+		// 出错前已导入的记录不会回滚，响应中附带已导入的数量
+		c.JSON(status, gin.H{
+			"error":    apiError{Code: code, Message: fmt.Sprintf("Error importing records: %v", err)},
+			"imported": response.Imported,
+			"failed":   response.Failed,
+			"files":    response.Files,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// importStream 导入请求体
+func (s *Server) importStream(c *gin.Context, response *bulkImportResponse, options ingest.Options) error {
+	result, err := ingest.Import(s.db, c.Request.Body, c.Query("source"), options)
+	response.add(result)
+	return err
+}
+
+// importFiles 导入 multipart 表单中 file 字段上传的文件，格式由 format 参数或文件扩展名决定
+func (s *Server) importFiles(c *gin.Context, response *bulkImportResponse, options ingest.Options) error {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return &ingest.InputError{Message: fmt.Sprintf("invalid multipart body: %v", err)}
+	}
+
+	// 逐个读取文件部分，避免将上传内容缓存到内存或临时文件
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return &ingest.InputError{Message: fmt.Sprintf("error reading multipart body: %v", err)}
+		}
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		fileOptions := options
+		fileOptions.Format = c.Query("format")
+		if fileOptions.Format == "" {
+			fileOptions.Format = ingest.DetectFormat(part.FileName())
+		}
+		result, err := ingest.Import(s.db, part, part.FileName(), fileOptions)
+		part.Close()
+		response.add(result)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (r *bulkImportResponse) add(result *ingest.Result) {
+	if result == nil {
+		return
+	}
+	r.Imported += result.Imported
+	r.Failed += result.Failed
+	r.Files = append(r.Files, result)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBulkImportRejectsInvalidInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := &Server{}
+
+	for _, tc := range []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+	}{
+		{"invalid split", "?split=hourly", "text/plain", "line"},
+		{"invalid format", "?format=xml", "multipart/form-data; boundary=x", ""},
+		{"multipart without boundary", "", "multipart/form-data", "--x\r\n"},
+		{"malformed multipart", "", "multipart/form-data; boundary=x", "not a multipart body"},
+		{"CSV without content column", "", "text/csv", "type,host\nlog,web-1\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/records/bulk"+tc.query, strings.NewReader(tc.body))
+			c.Request.Header.Set("Content-Type", tc.contentType)
+
+			s.HandleBulkImportRecords(c)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400: %s", w.Code, w.Body.String())
+			}
+			var body struct {
+				Error apiError `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Error.Code != ErrCodeValidationFailed {
+				t.Errorf("code = %q, want %q", body.Error.Code, ErrCodeValidationFailed)
+			}
+		})
+	}
+}
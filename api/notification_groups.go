@@ -0,0 +1,270 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+// notificationGroupRequest 创建或更新通知接收组的请求体
+type notificationGroupRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Channel     string   `json:"channel" binding:"required"`
+	Recipients  []string `json:"recipients" binding:"required,min=1"`
+	Description string   `json:"description"`
+}
+
+// notificationRouteRequest 创建或更新通知路由规则的请求体
+type notificationRouteRequest struct {
+	GroupID     int64  `json:"groupId" binding:"required"`
+	RecordType  string `json:"recordType"`
+	Tag         string `json:"tag"`
+	MinSeverity int    `json:"minSeverity" binding:"min=0,max=5"`
+	StartHour   *int   `json:"startHour" binding:"omitempty,min=0,max=23"`
+	EndHour     *int   `json:"endHour" binding:"omitempty,min=1,max=24"`
+	Priority    int    `json:"priority"`
+	Enabled     *bool  `json:"enabled"`
+}
+
+func (r *notificationRouteRequest) toRoute() *models.NotificationRoute {
+	route := &models.NotificationRoute{
+		GroupID:     r.GroupID,
+		RecordType:  r.RecordType,
+		Tag:         r.Tag,
+		MinSeverity: r.MinSeverity,
+		StartHour:   0,
+		EndHour:     24,
+		Priority:    r.Priority,
+		Enabled:     true,
+	}
+	if r.StartHour != nil {
+		route.StartHour = *r.StartHour
+	}
+	if r.EndHour != nil {
+		route.EndHour = *r.EndHour
+	}
+	if r.Enabled != nil {
+		route.Enabled = *r.Enabled
+	}
+	return route
+}
+
+func (s *Server) HandleListNotificationGroups(c *gin.Context) {
+	groups, err := models.ListNotificationGroups(s.db, tenantID(c))
+	if err != nil {
+		log.Printf("获取通知接收组失败: %v", err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting notification group: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, groups)
+}
+
+func (s *Server) HandleCreateNotificationGroup(c *gin.Context) {
+	var req notificationGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if !notification.IsSupportedChannel(req.Channel) {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Unsupported notification channel: %s", req.Channel))
+		return
+	}
+
+	group := &models.NotificationGroup{
+		TenantID:    tenantID(c),
+		Name:        req.Name,
+		Channel:     req.Channel,
+		Recipients:  req.Recipients,
+		Description: req.Description,
+	}
+	if err := models.CreateNotificationGroup(s.db, group); err != nil {
+		log.Printf("创建通知接收组失败: %v", err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error creating notification group: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, group)
+}
+
+func (s *Server) HandleGetNotificationGroup(c *gin.Context) {
+	group, ok := s.loadNotificationGroup(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, group)
+}
+
+func (s *Server) HandleUpdateNotificationGroup(c *gin.Context) {
+	group, ok := s.loadNotificationGroup(c)
+	if !ok {
+		return
+	}
+
+	var req notificationGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if !notification.IsSupportedChannel(req.Channel) {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Unsupported notification channel: %s", req.Channel))
+		return
+	}
+
+	group.Name = req.Name
+	group.Channel = req.Channel
+	group.Recipients = req.Recipients
+	group.Description = req.Description
+	found, err := models.UpdateNotificationGroup(s.db, group)
+	if err != nil {
+		log.Printf("更新通知接收组失败 (ID: %d): %v", group.ID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error updating notification group: %v", err))
+		return
+	}
+	if !found {
+		respondError(c, http.StatusNotFound, "Notification group not found")
+		return
+	}
+	c.JSON(http.StatusOK, group)
+}
+
+func (s *Server) HandleDeleteNotificationGroup(c *gin.Context) {
+	group, ok := s.loadNotificationGroup(c)
+	if !ok {
+		return
+	}
+
+	found, err := models.DeleteNotificationGroup(s.db, group.TenantID, group.ID)
+	if err != nil {
+		log.Printf("删除通知接收组失败 (ID: %d): %v", group.ID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error deleting notification group: %v", err))
+		return
+	}
+	if !found {
+		respondError(c, http.StatusNotFound, "Notification group not found")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// loadNotificationGroup 根据路径参数加载通知接收组，失败时已写入错误响应
+func (s *Server) loadNotificationGroup(c *gin.Context) (*models.NotificationGroup, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid notification group ID")
+		return nil, false
+	}
+
+	group, err := models.GetNotificationGroup(s.db, tenantID(c), id)
+	if err != nil {
+		log.Printf("获取通知接收组失败 (ID: %d): %v", id, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting notification group: %v", err))
+		return nil, false
+	}
+	if group == nil {
+		respondError(c, http.StatusNotFound, "Notification group not found")
+		return nil, false
+	}
+	return group, true
+}
+
+func (s *Server) HandleListNotificationRoutes(c *gin.Context) {
+	routes, err := models.ListNotificationRoutes(s.db, tenantID(c), false)
+	if err != nil {
+		log.Printf("获取通知路由规则失败: %v", err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing notification routes: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, routes)
+}
+
+func (s *Server) HandleCreateNotificationRoute(c *gin.Context) {
+	var req notificationRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	route := req.toRoute()
+	route.TenantID = tenantID(c)
+	if !s.checkRouteGroup(c, route.GroupID) {
+		return
+	}
+	if err := models.CreateNotificationRoute(s.db, route); err != nil {
+		log.Printf("创建通知路由规则失败: %v", err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error creating notification route: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, route)
+}
+
+func (s *Server) HandleUpdateNotificationRoute(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid notification route ID")
+		return
+	}
+
+	var req notificationRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	route := req.toRoute()
+	route.TenantID = tenantID(c)
+	route.ID = id
+	if !s.checkRouteGroup(c, route.GroupID) {
+		return
+	}
+	found, err := models.UpdateNotificationRoute(s.db, route)
+	if err != nil {
+		log.Printf("更新通知路由规则失败 (ID: %d): %v", id, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error updating notification route: %v", err))
+		return
+	}
+	if !found {
+		respondError(c, http.StatusNotFound, "Notification route not found")
+		return
+	}
+	c.JSON(http.StatusOK, route)
+}
+
+func (s *Server) HandleDeleteNotificationRoute(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid notification route ID")
+		return
+	}
+
+	found, err := models.DeleteNotificationRoute(s.db, tenantID(c), id)
+	if err != nil {
+		log.Printf("删除通知路由规则失败 (ID: %d): %v", id, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error deleting notification route: %v", err))
+		return
+	}
+	if !found {
+		respondError(c, http.StatusNotFound, "Notification route not found")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// checkRouteGroup 校验路由规则引用的接收组存在，失败时已写入错误响应
+func (s *Server) checkRouteGroup(c *gin.Context, groupID int64) bool {
+	group, err := models.GetNotificationGroup(s.db, tenantID(c), groupID)
+	if err != nil {
+		log.Printf("获取通知接收组失败 (ID: %d): %v", groupID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting notification group: %v", err))
+		return false
+	}
+	if group == nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Notification group %d not found", groupID))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ackRequest 确认通知的请求体
+type ackRequest struct {
+	By string `json:"by"`
+}
+
+// escalationPolicyRequest 创建或更新升级策略的请求体
+type escalationPolicyRequest struct {
+	Name              string `json:"name" binding:"required"`
+	SourceGroupID     int64  `json:"sourceGroupId"`
+	TargetGroupID     int64  `json:"targetGroupId" binding:"required"`
+	Level             int    `json:"level" binding:"omitempty,min=1"`
+	MinSeverity       int    `json:"minSeverity" binding:"min=0,max=5"`
+	AckTimeoutMinutes int    `json:"ackTimeoutMinutes" binding:"required,min=1"`
+	Enabled           *bool  `json:"enabled"`
+}
+
+func (r *escalationPolicyRequest) toPolicy() *models.EscalationPolicy {
+	policy := &models.EscalationPolicy{
+		Name:              r.Name,
+		SourceGroupID:     r.SourceGroupID,
+		TargetGroupID:     r.TargetGroupID,
+		Level:             r.Level,
+		MinSeverity:       r.MinSeverity,
+		AckTimeoutMinutes: r.AckTimeoutMinutes,
+		Enabled:           true,
+	}
+	if policy.Level == 0 {
+		policy.Level = 1
+	}
+	if r.Enabled != nil {
+		policy.Enabled = *r.Enabled
+	}
+	return policy
+}
+
+func (s *Server) HandleGetNotification(c *gin.Context) {
+	n, ok := s.loadNotification(c)
+	if !ok {
+		return
+	}
+
+	chain, err := models.GetEscalationChain(s.db, n.TenantID, n.ID)
+	if err != nil {
+		log.Printf("获取通知升级链失败 (ID: %d): %v", n.ID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting escalation chain: %v", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"notification": n, "escalations": chain})
+}
+
+// HandleAckNotification 通过 API 确认通知
+func (s *Server) HandleAckNotification(c *gin.Context) {
+	n, ok := s.loadNotification(c)
+	if !ok {
+		return
+	}
+
+	var req ackRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+			return
+		}
+	}
+	if req.By == "" {
+		req.By = principalName(c)
+	}
+
+	if err := models.AcknowledgeNotification(s.db, n.TenantID, n.ID, req.By); err != nil {
+		log.Printf("确认通知失败 (ID: %d): %v", n.ID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error acknowledging notification: %v", err))
+		return
+	}
+
+	n, ok = s.loadNotification(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, n)
+}
+
+// HandleAckNotificationLink 通过邮件或 Webhook 中的签名链接确认通知
+func (s *Server) HandleAckNotificationLink(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, "无效的通知ID")
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil || !notification.VerifyAckSignature(id, expires, c.Query("signature")) {
+		c.String(http.StatusForbidden, "确认链接无效或已过期")
+		return
+	}
+
+	tenant, err := models.GetNotificationTenantID(s.db, id)
+	if err != nil {
+		log.Printf("获取通知失败 (ID: %d): %v", id, err)
+		c.String(http.StatusInternalServerError, "获取通知失败")
+		return
+	}
+	if tenant == 0 {
+		c.String(http.StatusNotFound, "通知未找到")
+		return
+	}
+
+	if err := models.AcknowledgeNotification(s.db, tenant, id, "link"); err != nil {
+		log.Printf("确认通知失败 (ID: %d): %v", id, err)
+		c.String(http.StatusInternalServerError, "确认通知失败")
+		return
+	}
+	c.String(http.StatusOK, fmt.Sprintf("通知 #%d 已确认", id))
+}
+
+// loadNotification 根据路径参数加载通知，失败时已写入错误响应
+func (s *Server) loadNotification(c *gin.Context) (*models.Notification, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid notification ID")
+		return nil, false
+	}
+
+	n, err := models.GetNotification(s.db, tenantID(c), id)
+	if err != nil {
+		log.Printf("获取通知失败 (ID: %d): %v", id, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting notification: %v", err))
+		return nil, false
+	}
+	if n == nil {
+		respondError(c, http.StatusNotFound, "Notification not found")
+		return nil, false
+	}
+	return n, true
+}
+
+func (s *Server) HandleListEscalationPolicies(c *gin.Context) {
+	policies, err := models.ListEscalationPolicies(s.db, tenantID(c), false)
+	if err != nil {
+		log.Printf("获取升级策略失败: %v", err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing escalation policies: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, policies)
+}
+
+func (s *Server) HandleCreateEscalationPolicy(c *gin.Context) {
+	var req escalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	policy := req.toPolicy()
+	policy.TenantID = tenantID(c)
+	if !s.checkPolicyGroups(c, policy) {
+		return
+	}
+	if err := models.CreateEscalationPolicy(s.db, policy); err != nil {
+		log.Printf("创建升级策略失败: %v", err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error creating escalation policy: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+func (s *Server) HandleUpdateEscalationPolicy(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid escalation policy ID")
+		return
+	}
+
+	var req escalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	policy := req.toPolicy()
+	policy.TenantID = tenantID(c)
+	policy.ID = id
+	if !s.checkPolicyGroups(c, policy) {
+		return
+	}
+	found, err := models.UpdateEscalationPolicy(s.db, policy)
+	if err != nil {
+		log.Printf("更新升级策略失败 (ID: %d): %v", id, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error updating escalation policy: %v", err))
+		return
+	}
+	if !found {
+		respondError(c, http.StatusNotFound, "Escalation policy not found")
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+func (s *Server) HandleDeleteEscalationPolicy(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid escalation policy ID")
+		return
+	}
+
+	found, err := models.DeleteEscalationPolicy(s.db, tenantID(c), id)
+	if err != nil {
+		log.Printf("删除升级策略失败 (ID: %d): %v", id, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error deleting escalation policy: %v", err))
+		return
+	}
+	if !found {
+		respondError(c, http.StatusNotFound, "Escalation policy not found")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// checkPolicyGroups 校验升级策略引用的接收组存在，失败时已写入错误响应
+func (s *Server) checkPolicyGroups(c *gin.Context, policy *models.EscalationPolicy) bool {
+	if policy.SourceGroupID != 0 && !s.checkRouteGroup(c, policy.SourceGroupID) {
+		return false
+	}
+	return s.checkRouteGroup(c, policy.TargetGroupID)
+}
@@ -0,0 +1,353 @@
+package api
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openAPISpec 所有接口的 OpenAPI 3 描述，请求校验同样以它为准，修改接口时需要同步更新
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// maxJSONBodyBytes JSON 请求体的大小上限
+const maxJSONBodyBytes = 1 << 20
+
+// schema OpenAPI 中的 JSON Schema 对象
+type schema map[string]interface{}
+
+// openAPI 解析后的 OpenAPI 描述，用于校验请求体
+type openAPI struct {
+	schemas    map[string]schema
+	operations map[string]schema // "METHOD /api/records/{id}" 到请求体 schema
+}
+
+// mustLoadOpenAPI 解析内嵌的 OpenAPI 描述，描述无效属于编程错误，直接 panic
+func mustLoadOpenAPI() *openAPI {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			RequestBody *struct {
+				Required bool `json:"required"`
+				Content  map[string]struct {
+					Schema schema `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		panic(fmt.Sprintf("invalid openapi.json: %v", err))
+	}
+
+	spec := &openAPI{schemas: doc.Components.Schemas, operations: map[string]schema{}}
+	for path, methods := range doc.Paths {
+		for method, operation := range methods {
+			if operation.RequestBody == nil {
+				continue
+			}
+			content, ok := operation.RequestBody.Content["application/json"]
+			if !ok {
+				continue
+			}
+			body := schema{"requestBodyRequired": operation.RequestBody.Required}
+			for key, value := range content.Schema {
+				body[key] = value
+			}
+			spec.operations[strings.ToUpper(method)+" "+path] = body
+		}
+	}
+	return spec
+}
+
+// ginPathParam 匹配 gin 路由中的 :param
+var ginPathParam = regexp.MustCompile(`:(\w+)`)
+
+// requestSchema 返回路由的 JSON 请求体 schema，没有时返回 nil
+func (o *openAPI) requestSchema(method, fullPath string) schema {
+	return o.operations[method+" "+ginPathParam.ReplaceAllString(fullPath, "{$1}")]
+}
+
+// HandleOpenAPI 返回 OpenAPI 描述
+func (s *Server) HandleOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openAPISpec)
+}
+
+// swaggerUIPage 从 CDN 加载 Swagger UI，展示 /openapi.json
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+  <meta charset="utf-8">
+  <title>DeepSeek 数据分析 API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui", persistAuthorization: true });
+  </script>
+</body>
+</html>
+`
+
+// HandleSwaggerUI 返回 Swagger UI 页面
+func (s *Server) HandleSwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+// validateRequest 按 OpenAPI 描述校验 JSON 请求体：必填字段、字段类型、枚举取值和长度限制。
+// 校验失败时返回 400 和所有不符合要求的字段，请求体超过 maxJSONBodyBytes 时返回 413
+func (s *Server) validateRequest(c *gin.Context) {
+	bodySchema := s.openAPI.requestSchema(c.Request.Method, c.FullPath())
+	if bodySchema == nil {
+		c.Next()
+		return
+	}
+	if c.Request.ContentLength > maxJSONBodyBytes {
+		respondError(c, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("Request body must not exceed %d bytes", maxJSONBodyBytes))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxJSONBodyBytes+1))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Error reading request body")
+		return
+	}
+	if len(body) > maxJSONBodyBytes {
+		respondError(c, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("Request body must not exceed %d bytes", maxJSONBodyBytes))
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if bodySchema["requestBodyRequired"] == true {
+			respondValidationError(c, []fieldError{{Field: "body", Message: "is required"}})
+			return
+		}
+		c.Next()
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %v", err))
+		return
+	}
+
+	var errs []fieldError
+	s.openAPI.validate(bodySchema, value, "", &errs)
+	if len(errs) > 0 {
+		respondValidationError(c, errs)
+		return
+	}
+	c.Next()
+}
+
+// validate 按 schema 校验 value，支持本项目 OpenAPI 描述用到的 JSON Schema 关键字
+func (o *openAPI) validate(sch schema, value interface{}, field string, errs *[]fieldError) {
+	fail := func(format string, args ...interface{}) {
+		name := field
+		if name == "" {
+			name = "body"
+		}
+		*errs = append(*errs, fieldError{Field: name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if ref, ok := sch["$ref"].(string); ok {
+		o.validate(o.schemas[strings.TrimPrefix(ref, "#/components/schemas/")], value, field, errs)
+		return
+	}
+	if all, ok := sch["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			o.validate(asSchema(sub), value, field, errs)
+		}
+	}
+	if value == nil {
+		// 可选字段为 null 时视为未提供，由调用方处理
+		if sch["type"] != nil {
+			fail("must not be null")
+		}
+		return
+	}
+	if readOnly, _ := sch["readOnly"].(bool); readOnly {
+		return
+	}
+
+	switch sch["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		properties := asSchema(sch["properties"])
+		required := asStrings(sch["required"])
+		for _, name := range required {
+			if _, ok := object[name]; !ok {
+				*errs = append(*errs, fieldError{Field: joinField(field, name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if object[name] == nil && !containsString(required, name) {
+				continue
+			}
+			if property, ok := properties[name]; ok {
+				o.validate(asSchema(property), object[name], joinField(field, name), errs)
+				continue
+			}
+			switch additional := sch["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					*errs = append(*errs, fieldError{Field: joinField(field, name), Message: "is not allowed"})
+				}
+			case map[string]interface{}:
+				o.validate(additional, object[name], joinField(field, name), errs)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if min, ok := number(sch["minItems"]); ok && float64(len(items)) < min {
+			fail("must contain at least %v items", min)
+		}
+		if max, ok := number(sch["maxItems"]); ok && float64(len(items)) > max {
+			fail("must contain at most %v items", max)
+		}
+		if itemSchema, ok := sch["items"].(map[string]interface{}); ok {
+			for i, item := range items {
+				o.validate(itemSchema, item, fmt.Sprintf("%s[%d]", field, i), errs)
+			}
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		length := float64(utf8.RuneCountInString(text))
+		if min, ok := number(sch["minLength"]); ok && length < min {
+			if min == 1 {
+				fail("must not be empty")
+			} else {
+				fail("must be at least %v characters", min)
+			}
+		}
+		if max, ok := number(sch["maxLength"]); ok && length > max {
+			fail("must be at most %v characters", max)
+		}
+		if sch["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, text); err != nil {
+				fail("must be an RFC 3339 date-time")
+			}
+		}
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			fail("must be a %s", sch["type"])
+			return
+		}
+		if sch["type"] == "integer" {
+			if _, ok := new(big.Int).SetString(n.String(), 10); !ok {
+				fail("must be an integer")
+				return
+			}
+		}
+		f, err := n.Float64()
+		if err != nil {
+			fail("must be a %s", sch["type"])
+			return
+		}
+		if min, ok := number(sch["minimum"]); ok && f < min {
+			fail("must be at least %v", min)
+		}
+		if max, ok := number(sch["maximum"]); ok && f > max {
+			fail("must be at most %v", max)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be a boolean")
+			return
+		}
+	}
+
+	if enum, ok := sch["enum"].([]interface{}); ok {
+		for _, allowed := range enum {
+			if enumEqual(allowed, value) {
+				return
+			}
+		}
+		fail("must be one of %s", strings.Join(asStrings(enum), ", "))
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func asSchema(value interface{}) schema {
+	object, _ := value.(map[string]interface{})
+	return object
+}
+
+func asStrings(value interface{}) []string {
+	values, _ := value.([]interface{})
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, fmt.Sprint(v))
+	}
+	return result
+}
+
+// enumEqual 判断请求中的值是否等于 enum 中的值。文档中的数字解码为 float64，
+// 请求体中的数字解码为 json.Number，按数值比较
+func enumEqual(allowed, value interface{}) bool {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		a, isNumber := number(allowed)
+		return err == nil && isNumber && f == a
+	}
+	return allowed == value
+}
+
+func number(value interface{}) (float64, bool) {
+	f, ok := value.(float64)
+	return f, ok
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/analysis"
+)
+
+// 本文件中的操作与传输方式无关，HTTP 处理函数和 gRPC 服务都只负责解析请求和转换响应，
+// 业务逻辑统一在这里实现，保证两种接口的行为一致
+
+// opError 操作失败的原因，Status 为对应的 HTTP 状态码，gRPC 接口据此转换为 gRPC 状态码
+type opError struct {
+	Status  int
+	Code    string // 错误码，为空时由 Status 决定
+	Message string
+	Version int // 版本冲突时记录的当前版本号
+}
+
+func (e *opError) Error() string {
+	return e.Message
+}
+
+// errorCode 返回错误码
+func (e *opError) errorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	if code, ok := statusErrorCodes[e.Status]; ok {
+		return code
+	}
+	return ErrCodeInternal
+}
+
+// newOpError 创建错误码由状态码决定的操作错误
+func newOpError(status int, format string, args ...interface{}) *opError {
+	return &opError{Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+// asOpError 将任意错误转换为 opError，非 opError 视为内部错误
+func asOpError(err error) *opError {
+	if e, ok := err.(*opError); ok {
+		return e
+	}
+	return newOpError(http.StatusInternalServerError, "%v", err)
+}
+
+// getRecord 获取租户的记录，includeDeleted 为 false 时已删除的记录返回 410
+func (s *Server) getRecord(tenantID int64, id int64, includeDeleted bool) (*models.DataRecord, error) {
+	record, err := models.GetDataRecord(s.db, tenantID, id)
+	if err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error getting record: %v", err)
+	}
+	if record == nil {
+		return nil, newOpError(http.StatusNotFound, "Record not found")
+	}
+	if record.DeletedAt != nil && !includeDeleted {
+		return nil, newOpError(http.StatusGone, "Record has been deleted")
+	}
+	return record, nil
+}
+
+// getWritableRecord 获取待修改的记录，expectedVersion 必须与记录当前版本一致
+func (s *Server) getWritableRecord(tenantID int64, id int64, expectedVersion int) (*models.DataRecord, error) {
+	if expectedVersion <= 0 {
+		return nil, newOpError(http.StatusPreconditionRequired, "Expected record version is required")
+	}
+	record, err := s.getRecord(tenantID, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != record.Version {
+		return nil, &opError{
+			Status:  http.StatusPreconditionFailed,
+			Message: fmt.Sprintf("Record has been modified, current version is %d", record.Version),
+			Version: record.Version,
+		}
+	}
+	return record, nil
+}
+
+// createRecord 校验并以 principal 的身份创建记录，按配置自动分析
+func (s *Server) createRecord(principal *Principal, record *models.DataRecord) (*recordResponse, error) {
+	if err := models.ValidateRecord(record); err != nil {
+		return nil, newOpError(http.StatusBadRequest, "%v", err)
+	}
+	if err := models.PrepareRecordMetadata(record); err != nil {
+		return nil, newOpError(http.StatusBadRequest, "%v", err)
+	}
+	record.TenantID = principal.TenantID
+	record.CreatedBy = principal.Name
+	record.UpdatedBy = record.CreatedBy
+
+	if err := models.CreateDataRecord(s.db, record); err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error creating record: %v", err)
+	}
+	return &recordResponse{DataRecord: record, AutoAnalysis: s.autoAnalyze(record)}, nil
+}
+
+// updateRecord 校验并保存修改后的记录。内容相对 before 有变化时会生成新的内容版本，
+// 类型或内容变化时按配置自动分析，只修改元数据不会触发
+func (s *Server) updateRecord(principal *Principal, before *models.DataRecord, record *models.DataRecord) (*recordResponse, error) {
+	if err := models.ValidateRecord(record); err != nil {
+		return nil, newOpError(http.StatusBadRequest, "%v", err)
+	}
+	if err := models.PrepareRecordMetadata(record); err != nil {
+		return nil, newOpError(http.StatusBadRequest, "%v", err)
+	}
+	record.UpdatedBy = principal.Name
+
+	if err := models.UpdateDataRecord(s.db, record, recordContentChanged(before, record)); err != nil {
+		return nil, recordUpdateError(err)
+	}
+
+	response := &recordResponse{DataRecord: record}
+	if before.Type != record.Type || before.Content != record.Content {
+		response.AutoAnalysis = s.autoAnalyze(record)
+	}
+	return response, nil
+}
+
+// deleteRecord 软删除记录
+func (s *Server) deleteRecord(principal *Principal, id int64, expectedVersion int) error {
+	record, err := s.getWritableRecord(principal.TenantID, id, expectedVersion)
+	if err != nil {
+		return err
+	}
+	if err := models.SoftDeleteDataRecord(s.db, record.TenantID, record.ID, record.Version, principal.Name); err != nil {
+		return recordUpdateError(err)
+	}
+	return nil
+}
+
+// restoreRecord 恢复已删除的记录
+func (s *Server) restoreRecord(principal *Principal, id int64) (*models.DataRecord, error) {
+	record, err := s.getRecord(principal.TenantID, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if record.DeletedAt == nil {
+		return nil, newOpError(http.StatusConflict, "Record is not deleted")
+	}
+
+	if err := models.RestoreDataRecord(s.db, record.TenantID, record.ID, principal.Name); err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error restoring record: %v", err)
+	}
+	record, err = models.GetDataRecord(s.db, record.TenantID, record.ID)
+	if err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error getting record: %v", err)
+	}
+	return record, nil
+}
+
+// recordUpdateError 转换记录更新错误，版本冲突返回 412
+func recordUpdateError(err error) error {
+	if err == models.ErrVersionConflict {
+		return newOpError(http.StatusPreconditionFailed, "Record has been modified")
+	}
+	return newOpError(http.StatusInternalServerError, "Error updating record: %v", err)
+}
+
+// listRecordActions 获取记录的操作执行历史
+func (s *Server) listRecordActions(principal *Principal, id int64) ([]models.ActionExecution, error) {
+	record, err := s.getRecord(principal.TenantID, id, true)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := models.GetActionExecutions(s.db, record.TenantID, record.ID)
+	if err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error listing action executions: %v", err)
+	}
+	return executions, nil
+}
+
+// analyzeRecordByID 以 principal 的身份分析记录，已删除的记录不能分析
+func (s *Server) analyzeRecordByID(principal *Principal, id int64) (*models.AnalysisResult, error) {
+	result, err := s.analysis.AnalyzeByID(principal.TenantID, id, principal.Name)
+	switch err {
+	case nil:
+		return result, nil
+	case analysis.ErrRecordNotFound:
+		return nil, newOpError(http.StatusNotFound, "Record not found")
+	case analysis.ErrRecordDeleted:
+		return nil, newOpError(http.StatusGone, "Record has been deleted")
+	case analysis.ErrBudgetExceeded:
+		return nil, &opError{
+			Status:  http.StatusTooManyRequests,
+			Code:    ErrCodeBudgetExceeded,
+			Message: "Tenant monthly DeepSeek token budget exceeded",
+		}
+	default:
+		return nil, newOpError(http.StatusInternalServerError, "%v", err)
+	}
+}
+
+// createAnalysisBatch 为指定的记录或符合条件的记录创建批量分析任务，
+// recordIDs 和 selector 二选一，limit 大于 0 时进一步限制任务包含的记录数
+func (s *Server) createAnalysisBatch(principal *Principal, recordIDs []int64, selector *models.RecordSelector, limit int) (*models.AnalysisBatch, error) {
+	if (len(recordIDs) == 0) == (selector == nil) {
+		return nil, newOpError(http.StatusBadRequest, "Exactly one of ids or filter is required")
+	}
+
+	maxRecords := batchMaxRecords()
+	if limit > 0 && limit < maxRecords {
+		maxRecords = limit
+	}
+
+	if selector != nil {
+		var err error
+		if recordIDs, err = models.SelectRecordIDs(s.db, selector.RecordFilter(principal.TenantID), maxRecords); err != nil {
+			return nil, newOpError(http.StatusBadRequest, "%v", err)
+		}
+	}
+	if len(recordIDs) == 0 {
+		return nil, newOpError(http.StatusBadRequest, "No records to analyze")
+	}
+	if len(recordIDs) > maxRecords {
+		return nil, newOpError(http.StatusBadRequest, "A batch may contain at most %d records", maxRecords)
+	}
+
+	batch, err := s.StartAnalysisBatch(principal.TenantID, recordIDs, principal.Name)
+	if err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error creating analysis batch: %v", err)
+	}
+	return batch, nil
+}
+
+// getAnalysisBatch 获取批量分析任务，任务结束后同时返回汇总报告
+func (s *Server) getAnalysisBatch(principal *Principal, id int64) (*models.AnalysisBatch, *models.AnalysisBatchReport, error) {
+	batch, err := s.findAnalysisBatch(principal, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if batch.Status != models.BatchStatusCompleted && batch.Status != models.BatchStatusCancelled {
+		return batch, nil, nil
+	}
+
+	report, err := models.GetAnalysisBatchReport(s.db, batch.TenantID, batch.ID)
+	if err != nil {
+		return nil, nil, newOpError(http.StatusInternalServerError, "Error getting batch report: %v", err)
+	}
+	return batch, report, nil
+}
+
+// cancelAnalysisBatch 取消未结束的批量分析任务
+func (s *Server) cancelAnalysisBatch(principal *Principal, id int64) (*models.AnalysisBatch, error) {
+	batch, err := s.findAnalysisBatch(principal, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.batches.Cancel(batch.TenantID, batch.ID)
+	if err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error cancelling batch: %v", err)
+	}
+	if !cancelled {
+		return nil, newOpError(http.StatusConflict, "Batch is already %s", batch.Status)
+	}
+	return s.findAnalysisBatch(principal, batch.ID)
+}
+
+// findAnalysisBatch 获取租户的批量分析任务
+func (s *Server) findAnalysisBatch(principal *Principal, id int64) (*models.AnalysisBatch, error) {
+	batch, err := models.GetAnalysisBatch(s.db, principal.TenantID, id)
+	if err != nil {
+		return nil, newOpError(http.StatusInternalServerError, "Error getting batch: %v", err)
+	}
+	if batch == nil {
+		return nil, newOpError(http.StatusNotFound, "Batch not found")
+	}
+	return batch, nil
+}
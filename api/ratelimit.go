@@ -0,0 +1,56 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"deepseek_golang_demo/services/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimit 按调用方限流，超出限额时返回 429 和 Retry-After。
+// 通过 API Key 认证的请求按 API Key 计数，其他请求按客户端 IP 计数；
+// 发起分析的接口会调用 DeepSeek，使用单独的 analyze 限额
+func (s *Server) rateLimit(c *gin.Context) {
+	class := ratelimit.ClassAPI
+	if c.Request.Method == http.MethodPost && strings.HasPrefix(c.FullPath(), "/api/analyze/") &&
+		!strings.HasSuffix(c.FullPath(), "/cancel") {
+		class = ratelimit.ClassAnalyze
+	}
+
+	allowed, retryAfter := s.limiter.Allow(class, rateLimitKey(currentPrincipal(c), c.ClientIP()))
+	if !allowed {
+		respondRateLimited(c, retryAfter)
+		return
+	}
+	c.Next()
+}
+
+// rateLimitIP 在认证之前按客户端 IP 限流，未认证和 API Key 无效的请求同样计入限额
+func (s *Server) rateLimitIP(c *gin.Context) {
+	allowed, retryAfter := s.limiter.Allow(ratelimit.ClassAuth, rateLimitKey(nil, c.ClientIP()))
+	if !allowed {
+		respondRateLimited(c, retryAfter)
+		return
+	}
+	c.Next()
+}
+
+// respondRateLimited 返回 429 和 Retry-After
+func respondRateLimited(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	respondError(c, http.StatusTooManyRequests, "Too many requests")
+}
+
+// rateLimitKey 限流计数的键，通过 API Key 认证时按 API Key 计数，否则按客户端 IP 计数
+func rateLimitKey(principal *Principal, clientIP string) string {
+	if principal != nil && principal != anonymousPrincipal {
+		return fmt.Sprintf("key:%d:%s", principal.TenantID, principal.Name)
+	}
+	return "ip:" + clientIP
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// updateRecordRequest PUT 请求体，整体替换记录内容
+type updateRecordRequest struct {
+	Type     string          `json:"type" binding:"required"`
+	Content  string          `json:"content" binding:"required"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// patchRecordRequest PATCH 请求体，只更新提供的字段
+type patchRecordRequest struct {
+	Type     *string          `json:"type"`
+	Content  *string          `json:"content"`
+	Metadata *json.RawMessage `json:"metadata"`
+}
+
+func (s *Server) HandleUpdateRecord(c *gin.Context) {
+	record, ok := s.loadRecordForWrite(c)
+	if !ok {
+		return
+	}
+
+	var req updateRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	before := *record
+	record.Type = req.Type
+	record.Content = req.Content
+	record.Metadata = req.Metadata
+	s.saveRecord(c, &before, record)
+}
+
+func (s *Server) HandlePatchRecord(c *gin.Context) {
+	record, ok := s.loadRecordForWrite(c)
+	if !ok {
+		return
+	}
+
+	var req patchRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	before := *record
+	if req.Type != nil {
+		if *req.Type == "" {
+			respondError(c, http.StatusBadRequest, "type must not be empty")
+			return
+		}
+		record.Type = *req.Type
+	}
+	if req.Content != nil {
+		if *req.Content == "" {
+			respondError(c, http.StatusBadRequest, "content must not be empty")
+			return
+		}
+		record.Content = *req.Content
+	}
+	if req.Metadata != nil {
+		record.Metadata = *req.Metadata
+	}
+	s.saveRecord(c, &before, record)
+}
+
+func (s *Server) HandleDeleteRecord(c *gin.Context) {
+	id, version, ok := recordWriteParams(c)
+	if !ok {
+		return
+	}
+
+	if err := s.deleteRecord(currentPrincipal(c), id, version); err != nil {
+		respondOpError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) HandleRestoreRecord(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid record ID")
+		return
+	}
+
+	record, err := s.restoreRecord(currentPrincipal(c), id)
+	if err != nil {
+		respondOpError(c, err)
+		return
+	}
+	setRecordETag(c, record)
+	c.JSON(http.StatusOK, record)
+}
+
+// loadRecord 根据路径参数加载记录（包括已删除的记录），失败时已写入错误响应
+func (s *Server) loadRecord(c *gin.Context) (*models.DataRecord, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid record ID")
+		return nil, false
+	}
+
+	record, err := s.getRecord(tenantID(c), id, true)
+	if err != nil {
+		respondOpError(c, err)
+		return nil, false
+	}
+	return record, true
+}
+
+// loadRecordForWrite 加载待修改的记录并校验 If-Match 请求头，失败时已写入错误响应
+func (s *Server) loadRecordForWrite(c *gin.Context) (*models.DataRecord, bool) {
+	id, version, ok := recordWriteParams(c)
+	if !ok {
+		return nil, false
+	}
+
+	record, err := s.getWritableRecord(tenantID(c), id, version)
+	if err != nil {
+		respondOpError(c, err)
+		return nil, false
+	}
+	return record, true
+}
+
+// recordWriteParams 解析路径参数中的记录ID和 If-Match 请求头中的版本号。
+// 修改和删除记录必须携带 If-Match，未携带时返回 428，失败时已写入错误响应
+func recordWriteParams(c *gin.Context) (int64, int, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid record ID")
+		return 0, 0, false
+	}
+
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		respondError(c, http.StatusPreconditionRequired, "If-Match header is required")
+		return 0, 0, false
+	}
+	version, err := parseETagVersion(ifMatch)
+	if err != nil || version <= 0 {
+		respondError(c, http.StatusBadRequest, "Invalid If-Match header")
+		return 0, 0, false
+	}
+	return id, version, true
+}
+
+// saveRecord 保存修改后的记录并写入响应
+func (s *Server) saveRecord(c *gin.Context, before *models.DataRecord, record *models.DataRecord) {
+	response, err := s.updateRecord(currentPrincipal(c), before, record)
+	if err != nil {
+		respondOpError(c, err)
+		return
+	}
+	setRecordETag(c, record)
+	c.JSON(http.StatusOK, response)
+}
+
+// recordContentChanged 判断记录的类型、内容或元数据是否发生变化
+func recordContentChanged(before *models.DataRecord, after *models.DataRecord) bool {
+	return before.Type != after.Type || before.Content != after.Content ||
+		!models.MetadataEqual(before.Metadata, after.Metadata)
+}
+
+// setRecordETag 以记录版本号作为 ETag
+func setRecordETag(c *gin.Context, record *models.DataRecord) {
+	c.Header("ETag", fmt.Sprintf("%q", strconv.Itoa(record.Version)))
+}
+
+// parseETagVersion 解析 If-Match 中的版本号，支持 "3"、W/"3" 和 3
+func parseETagVersion(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+	return strconv.Atoi(value)
+}
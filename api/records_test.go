@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecordWriteParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, tc := range []struct {
+		name    string
+		ifMatch string
+		status  int
+		code    string
+		version int
+	}{
+		{"missing If-Match", "", http.StatusPreconditionRequired, ErrCodePreconditionRequired, 0},
+		{"invalid If-Match", `"abc"`, http.StatusBadRequest, ErrCodeInvalidRequest, 0},
+		{"zero version", `"0"`, http.StatusBadRequest, ErrCodeInvalidRequest, 0},
+		{"quoted version", `"3"`, http.StatusOK, "", 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/api/records/1", nil)
+			if tc.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tc.ifMatch)
+			}
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+			id, version, ok := recordWriteParams(c)
+			if tc.code == "" {
+				if !ok || id != 1 || version != tc.version {
+					t.Fatalf("recordWriteParams = (%d, %d, %v), want (1, %d, true)", id, version, ok, tc.version)
+				}
+				return
+			}
+			if ok {
+				t.Fatalf("recordWriteParams accepted If-Match %q", tc.ifMatch)
+			}
+			if w.Code != tc.status {
+				t.Errorf("status = %d, want %d", w.Code, tc.status)
+			}
+			var body struct {
+				Error apiError `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Error.Code != tc.code {
+				t.Errorf("code = %q, want %q", body.Error.Code, tc.code)
+			}
+		})
+	}
+}
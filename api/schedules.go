@@ -0,0 +1,164 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/scheduler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// scheduleRequest 创建或更新定时分析规则的请求体
+type scheduleRequest struct {
+	Name       string                `json:"name" binding:"required"`
+	Cron       string                `json:"cron" binding:"required"`
+	Mode       string                `json:"mode"`
+	Filter     models.RecordSelector `json:"filter"`
+	MaxRecords int                   `json:"maxRecords" binding:"min=0"`
+	Enabled    *bool                 `json:"enabled"`
+}
+
+// toSchedule 校验请求并填充规则，启用的规则重新计算下次执行时间
+func (r *scheduleRequest) toSchedule(schedule *models.Schedule) error {
+	if r.Mode == "" {
+		r.Mode = models.ScheduleModeAll
+	}
+	if !models.IsValidScheduleMode(r.Mode) {
+		return fmt.Errorf("unsupported mode: %s", r.Mode)
+	}
+	if limit := batchMaxRecords(); r.MaxRecords > limit {
+		return fmt.Errorf("maxRecords must not exceed %d", limit)
+	}
+	next, err := scheduler.NextRun(r.Cron, time.Now())
+	if err != nil {
+		return err
+	}
+
+	schedule.Name = r.Name
+	schedule.Cron = r.Cron
+	schedule.Mode = r.Mode
+	schedule.Filter = r.Filter
+	schedule.MaxRecords = r.MaxRecords
+	schedule.Enabled = r.Enabled == nil || *r.Enabled
+	schedule.NextRunAt = &next
+	return nil
+}
+
+// RunScheduler 运行定时分析调度器，多个实例中只有获得 MySQL 调度锁的实例执行规则，阻塞直到进程退出
+func (s *Server) RunScheduler(interval time.Duration) {
+	scheduler.New(s.db, s.StartAnalysisBatch, batchMaxRecords()).Run(interval)
+}
+
+func (s *Server) HandleListSchedules(c *gin.Context) {
+	schedules, err := models.ListSchedules(s.db, tenantID(c))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, schedules)
+}
+
+func (s *Server) HandleCreateSchedule(c *gin.Context) {
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	schedule := &models.Schedule{TenantID: tenantID(c)}
+	if err := req.toSchedule(schedule); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := models.CreateSchedule(s.db, schedule); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusCreated, schedule)
+}
+
+func (s *Server) HandleGetSchedule(c *gin.Context) {
+	schedule, ok := s.loadSchedule(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (s *Server) HandleUpdateSchedule(c *gin.Context) {
+	schedule, ok := s.loadSchedule(c)
+	if !ok {
+		return
+	}
+
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if err := req.toSchedule(schedule); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := models.UpdateSchedule(s.db, schedule); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (s *Server) HandleDeleteSchedule(c *gin.Context) {
+	schedule, ok := s.loadSchedule(c)
+	if !ok {
+		return
+	}
+	if err := models.DeleteSchedule(s.db, schedule.TenantID, schedule.ID); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// HandleRunSchedule 将规则的下次执行时间设为当前时间，由调度器在下一次检查时执行
+func (s *Server) HandleRunSchedule(c *gin.Context) {
+	schedule, ok := s.loadSchedule(c)
+	if !ok {
+		return
+	}
+	if !schedule.Enabled {
+		respondError(c, http.StatusConflict, "Schedule is disabled")
+		return
+	}
+
+	now := time.Now()
+	schedule.NextRunAt = &now
+	if err := models.UpdateSchedule(s.db, schedule); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusAccepted, schedule)
+}
+
+// loadSchedule 根据路径参数加载定时规则，失败时已写入错误响应
+func (s *Server) loadSchedule(c *gin.Context) (*models.Schedule, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid schedule ID")
+		return nil, false
+	}
+
+	schedule, err := models.GetSchedule(s.db, tenantID(c), id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	if schedule == nil {
+		respondError(c, http.StatusNotFound, "Schedule not found")
+		return nil, false
+	}
+	return schedule, true
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordTagRequest 为记录添加标签的请求体
+type recordTagRequest struct {
+	Tag string `json:"tag" binding:"required"`
+}
+
+// tagMergeRequest 合并标签的请求体
+type tagMergeRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+// tagAliasRequest 创建标签别名的请求体
+type tagAliasRequest struct {
+	Alias   string `json:"alias" binding:"required"`
+	TagName string `json:"tagName" binding:"required"`
+}
+
+// vocabularyTagRequest 添加词表标签的请求体
+type vocabularyTagRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+func (s *Server) HandleListRecordTags(c *gin.Context) {
+	record, ok := s.loadRecord(c)
+	if !ok {
+		return
+	}
+
+	tags, err := models.GetTagsByRecordID(s.db, record.TenantID, record.ID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting tags: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+func (s *Server) HandleAddRecordTag(c *gin.Context) {
+	record, ok := s.loadRecord(c)
+	if !ok {
+		return
+	}
+	if record.DeletedAt != nil {
+		respondError(c, http.StatusGone, "Record has been deleted")
+		return
+	}
+
+	var req recordTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	tag := strings.TrimSpace(req.Tag)
+	if tag == "" || len(tag) > 255 {
+		respondError(c, http.StatusBadRequest, "Tag must be 1-255 characters")
+		return
+	}
+
+	if _, err := models.AddTag(s.db, record.TenantID, record.ID, tag, models.TagSourceHuman); err != nil {
+		if errors.Is(err, models.ErrTagNotInVocabulary) {
+			respondError(c, http.StatusUnprocessableEntity, fmt.Sprintf("Tag %q is not in the vocabulary", tag))
+			return
+		}
+		log.Printf("添加标签失败 (ID: %d): %v", record.ID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error adding tag: %v", err))
+		return
+	}
+
+	tags, err := models.GetTagsByRecordID(s.db, record.TenantID, record.ID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting tags: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+func (s *Server) HandleDeleteRecordTag(c *gin.Context) {
+	record, ok := s.loadRecord(c)
+	if !ok {
+		return
+	}
+	if record.DeletedAt != nil {
+		respondError(c, http.StatusGone, "Record has been deleted")
+		return
+	}
+
+	removed, err := models.RemoveTag(s.db, record.TenantID, record.ID, c.Param("tag"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error removing tag: %v", err))
+		return
+	}
+	if !removed {
+		respondError(c, http.StatusNotFound, "Tag not found")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) HandleListTags(c *gin.Context) {
+	usages, err := models.ListTagUsage(s.db, tenantID(c))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing tags: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, usages)
+}
+
+func (s *Server) HandleMergeTags(c *gin.Context) {
+	var req tagMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.From == req.To {
+		respondError(c, http.StatusBadRequest, "Cannot merge a tag into itself")
+		return
+	}
+
+	merged, err := models.MergeTags(s.db, tenantID(c), req.From, req.To)
+	if err != nil {
+		log.Printf("合并标签失败 (%s -> %s): %v", req.From, req.To, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error merging tags: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"from": req.From, "to": req.To, "merged": merged})
+}
+
+func (s *Server) HandleListTagAliases(c *gin.Context) {
+	aliases, err := models.ListTagAliases(s.db, tenantID(c))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error listing tag aliases: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, aliases)
+}
+
+func (s *Server) HandleCreateTagAlias(c *gin.Context) {
+	var req tagAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Alias == req.TagName {
+		respondError(c, http.StatusBadRequest, "Alias must differ from tag name")
+		return
+	}
+
+	// 别名不能指向另一个别名，统一指向规范名称
+	tagName, err := models.ResolveTagName(s.db, tenantID(c), req.TagName)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error resolving tag: %v", err))
+		return
+	}
+
+	alias := &models.TagAlias{Alias: req.Alias, TagName: tagName}
+	if err := models.CreateTagAlias(s.db, tenantID(c), alias); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusCreated, alias)
+}
+
+func (s *Server) HandleDeleteTagAlias(c *gin.Context) {
+	if err := models.DeleteTagAlias(s.db, tenantID(c), c.Param("alias")); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Server) HandleListVocabulary(c *gin.Context) {
+	tags, err := models.ListVocabularyTags(s.db, tenantID(c))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+func (s *Server) HandleAddVocabularyTag(c *gin.Context) {
+	var req vocabularyTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tag := &models.VocabularyTag{Name: req.Name, Description: req.Description}
+	if err := models.AddVocabularyTag(s.db, tenantID(c), tag); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusCreated, tag)
+}
+
+func (s *Server) HandleDeleteVocabularyTag(c *gin.Context) {
+	if err := models.DeleteVocabularyTag(s.db, tenantID(c), c.Param("name")); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
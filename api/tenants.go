@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tenantResponse 当前租户及其当月用量
+type tenantResponse struct {
+	*models.Tenant
+	Usage *models.TenantUsage `json:"usage"`
+}
+
+// tenantRequest 更新当前租户的请求，字段为空表示不修改；预算只能通过命令行修改
+type tenantRequest struct {
+	Name            *string           `json:"name"`
+	DeepSeekAPIKey  *string           `json:"deepseekApiKey"`  // 空字符串表示改用 DEEPSEEK_API_KEY
+	PromptTemplates map[string]string `json:"promptTemplates"` // 模板为空字符串时删除该类型的覆盖
+}
+
+// HandleGetTenant 获取调用方所属租户的配置和当月 DeepSeek 用量
+func (s *Server) HandleGetTenant(c *gin.Context) {
+	tenant, ok := s.loadTenant(c)
+	if !ok {
+		return
+	}
+	usage, err := models.GetTenantUsage(s.db, tenant.ID)
+	if err != nil {
+		log.Printf("获取租户用量失败 (ID: %d): %v", tenant.ID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting tenant usage: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, tenantResponse{Tenant: tenant, Usage: usage})
+}
+
+// HandleUpdateTenant 更新调用方所属租户的名称、DeepSeek API Key 和提示词模板
+func (s *Server) HandleUpdateTenant(c *gin.Context) {
+	tenant, ok := s.loadTenant(c)
+	if !ok {
+		return
+	}
+
+	var req tenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if req.Name != nil {
+		if *req.Name == "" {
+			respondError(c, http.StatusBadRequest, "name must not be empty")
+			return
+		}
+		tenant.Name = *req.Name
+	}
+	if req.DeepSeekAPIKey != nil {
+		tenant.DeepSeekAPIKey = *req.DeepSeekAPIKey
+	}
+	for recordType, template := range req.PromptTemplates {
+		if template == "" {
+			delete(tenant.PromptTemplates, recordType)
+		} else {
+			tenant.PromptTemplates[recordType] = template
+		}
+	}
+
+	if err := models.UpdateTenant(s.db, tenant); err != nil {
+		log.Printf("更新租户失败 (ID: %d): %v", tenant.ID, err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error updating tenant: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, tenant)
+}
+
+// loadTenant 加载调用方所属的租户，失败时已写入错误响应
+func (s *Server) loadTenant(c *gin.Context) (*models.Tenant, bool) {
+	tenant, err := models.GetTenant(s.db, tenantID(c))
+	if err != nil {
+		log.Printf("获取租户失败 (ID: %d): %v", tenantID(c), err)
+		respondError(c, http.StatusInternalServerError, fmt.Sprintf("Error getting tenant: %v", err))
+		return nil, false
+	}
+	if tenant == nil {
+		respondError(c, http.StatusNotFound, "Tenant not found")
+		return nil, false
+	}
+	return tenant, true
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+
+	"deepseek_golang_demo/models"
+)
+
+// runAPIKey 管理 API Key：
+//
+//	go run . apikey create -name ops-bot -role analyst [-tenant default]
+//	go run . apikey revoke -name ops-bot [-tenant default]
+//	go run . apikey list
+func runAPIKey(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: apikey create|revoke|list [flags]")
+	}
+
+	flags := flag.NewFlagSet("apikey "+args[0], flag.ContinueOnError)
+	name := flags.String("name", "", "API Key 名称，作为操作者记录在审计字段中")
+	role := flags.String("role", models.RoleReader, "角色：reader、analyst、approver 或 admin")
+	tenantSlug := flags.String("tenant", "default", "API Key 所属的租户标识")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "create":
+		if *name == "" {
+			return fmt.Errorf("-name is required")
+		}
+		tenant, err := lookupTenant(db, *tenantSlug)
+		if err != nil {
+			return err
+		}
+		apiKey, key, err := models.CreateAPIKey(db, tenant.ID, *name, *role)
+		if err != nil {
+			return err
+		}
+		// 密钥只在创建时输出一次，数据库中仅保存哈希
+		fmt.Printf("created api key %q (tenant: %s, role: %s)\n%s\n", apiKey.Name, tenant.Slug, apiKey.Role, key)
+	case "revoke":
+		if *name == "" {
+			return fmt.Errorf("-name is required")
+		}
+		tenant, err := lookupTenant(db, *tenantSlug)
+		if err != nil {
+			return err
+		}
+		revoked, err := models.RevokeAPIKey(db, tenant.ID, *name)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return fmt.Errorf("api key %q not found in tenant %s or already revoked", *name, tenant.Slug)
+		}
+		fmt.Printf("revoked api key %q (tenant: %s)\n", *name, tenant.Slug)
+	case "list":
+		apiKeys, err := models.ListAPIKeys(db)
+		if err != nil {
+			return err
+		}
+		for _, apiKey := range apiKeys {
+			status := "active"
+			if apiKey.RevokedAt != nil {
+				status = "revoked"
+			}
+			lastUsed := "never"
+			if apiKey.LastUsedAt != nil {
+				lastUsed = apiKey.LastUsedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-24s tenant %-4d %-9s %s...  %-8s last used: %s\n",
+				apiKey.Name, apiKey.TenantID, apiKey.Role, apiKey.Prefix, status, lastUsed)
+		}
+	default:
+		return fmt.Errorf("unknown apikey command: %s", args[0])
+	}
+	return nil
+}
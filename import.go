@@ -0,0 +1,72 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"deepseek_golang_demo/services/ingest"
+)
+
+// runImport 从本地文件批量导入记录：
+//
+//	go run . import [-tenant default] [-type log] [-format csv] [-split incident] [-window 5m] file...
+func runImport(db *sql.DB, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	tenantSlug := flags.String("tenant", "default", "导入到的租户标识")
+	recordType := flags.String("type", "", "未指定 type 的行使用的数据类型，日志文件默认为 log")
+	format := flags.String("format", "", "导入格式：ndjson、csv 或 log，默认按文件扩展名判断")
+	split := flags.String("split", ingest.SplitNone, "日志文件拆分方式：none、incident 或 window")
+	window := flags.Duration("window", 0, "split=window 时的时间窗口，默认 5m")
+	batchSize := flags.Int("batch", ingest.DefaultBatchSize, "每批插入的记录数")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: import [flags] file...")
+	}
+	tenant, err := lookupTenant(db, *tenantSlug)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, path := range flags.Args() {
+		options := ingest.Options{
+			Format:    *format,
+			Type:      *recordType,
+			Split:     *split,
+			Window:    *window,
+			BatchSize: *batchSize,
+			TenantID:  tenant.ID,
+			CreatedBy: "cli",
+		}
+		if options.Format == "" {
+			options.Format = ingest.DetectFormat(path)
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		result, err := ingest.Import(db, file, filepath.Base(path), options)
+		file.Close()
+		if result != nil {
+			fmt.Printf("%s: imported %d, failed %d\n", path, result.Imported, result.Failed)
+			for _, lineErr := range result.Errors {
+				fmt.Printf("  line %d: %s\n", lineErr.Line, lineErr.Error)
+			}
+			failed += result.Failed
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d lines could not be imported", failed)
+	}
+	return nil
+}
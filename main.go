@@ -4,38 +4,55 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"strings"
+	"time"
 
 	"deepseek_golang_demo/api"
 	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/batch"
 	"deepseek_golang_demo/services/deepseek"
+	"deepseek_golang_demo/services/notification"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	"github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/joho/godotenv"
 )
 
-// runDatabaseMigrations 执行数据库迁移
+// runDatabaseMigrations 执行数据库迁移，迁移文件按数据库类型放在 migrations/<数据库类型> 下
 func runDatabaseMigrations(db *sql.DB) (*migrate.Migrate, error) {
+	dialect := models.DialectOf(db)
+
 	// 创建file source实例
-	fsrc, err := (&file.File{}).Open("file://migrations")
+	fsrc, err := (&file.File{}).Open("file://migrations/" + string(dialect))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file source: %v", err)
 	}
 
-	// 创建mysql driver实例
-	config := mysql.Config{}
-	driver, err := mysql.WithInstance(db, &config)
+	// 创建数据库driver实例
+	var driver database.Driver
+	switch dialect {
+	case models.DialectSQLite:
+		driver, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	case models.DialectPostgres:
+		driver, err = postgres.WithInstance(db, &postgres.Config{})
+	default:
+		driver, err = mysql.WithInstance(db, &mysql.Config{})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create mysql driver: %v", err)
+		return nil, fmt.Errorf("failed to create %s driver: %v", dialect, err)
 	}
 
 	// 创建migrate实例
 	m, err := migrate.NewWithInstance(
 		"file", fsrc,
-		"mysql", driver,
+		string(dialect), driver,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create migrate instance: %v", err)
@@ -56,10 +73,9 @@ func main() {
 	}
 
 	// 获取配置
-	apiKey := os.Getenv("DEEPSEEK_API_KEY")
 	dbDSN := os.Getenv("DB_DSN")
-	if apiKey == "" || dbDSN == "" {
-		log.Fatal("DEEPSEEK_API_KEY and DB_DSN must be set in .env file")
+	if dbDSN == "" {
+		log.Fatal("DB_DSN must be set in .env file")
 	}
 
 	// 初始化数据库连接
@@ -86,14 +102,95 @@ func main() {
 		}
 	}()
 
+	// 子命令
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(db, os.Args[2:]); err != nil {
+			log.Fatalf("Import failed: %v", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := runAPIKey(db, os.Args[2:]); err != nil {
+			log.Fatalf("API key command failed: %v", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "tenant" {
+		if err := runTenant(db, os.Args[2:]); err != nil {
+			log.Fatalf("Tenant command failed: %v", err)
+		}
+		return
+	}
+
+	apiKey := os.Getenv("DEEPSEEK_API_KEY")
+	if apiKey == "" {
+		log.Fatal("DEEPSEEK_API_KEY must be set in .env file")
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "analyze" {
+		if err := runAnalyze(db, deepseek.NewClient(apiKey), os.Args[2:]); err != nil {
+			log.Fatalf("Analyze failed: %v", err)
+		}
+		return
+	}
+
+	// 启动通知汇总任务
+	if interval := notification.DigestInterval(); interval > 0 {
+		go notification.RunDigestLoop(db, interval)
+	}
+
+	// 启动通知升级检查任务
+	go notification.RunEscalationLoop(db, time.Minute)
+
 	// 初始化DeepSeek客户端
 	deepseekCli := deepseek.NewClient(apiKey)
 
 	// 初始化HTTP服务器
 	server := api.NewServer(db, deepseekCli)
 	router := gin.Default()
+	// 限流按客户端 IP 计数，只信任 TRUSTED_PROXIES 中代理设置的 X-Forwarded-For
+	if value := os.Getenv("TRUSTED_PROXIES"); value != "" {
+		if err := router.SetTrustedProxies(strings.Split(value, ",")); err != nil {
+			log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+		}
+	} else if err := router.SetTrustedProxies(nil); err != nil {
+		log.Fatalf("Failed to configure trusted proxies: %v", err)
+	}
 	server.SetupRoutes(router)
 
+	// 继续执行未完成的批量分析任务
+	if err := server.ResumeBatches(); err != nil {
+		log.Printf("Failed to resume analysis batches: %v", err)
+	}
+	go server.RunBatchRecovery(batch.LeaseTimeout)
+
+	// 定期清理过期的幂等键
+	go server.RunIdempotencyCleanup(time.Hour)
+
+	// 启动定时分析调度器
+	if os.Getenv("SCHEDULER_ENABLED") != "false" {
+		go server.RunScheduler(15 * time.Second)
+	}
+
+	// 启动gRPC服务器，与HTTP接口共用同一个Server
+	if os.Getenv("GRPC_ENABLED") != "false" {
+		grpcPort := os.Getenv("GRPC_PORT")
+		if grpcPort == "" {
+			grpcPort = "9090"
+		}
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port: %v", err)
+		}
+		grpcServer := server.NewGRPCServer()
+		fmt.Printf("gRPC server starting on port %s...\n", grpcPort)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// 启动服务器
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1 @@
+ALTER TABLE analysis_results DROP COLUMN severity;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE analysis_results
+    ADD COLUMN severity TINYINT NOT NULL DEFAULT 0 AFTER confidence;
\ No newline at end of file
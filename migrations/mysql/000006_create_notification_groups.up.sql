@@ -0,0 +1,11 @@
+CREATE TABLE
+    IF NOT EXISTS notification_groups (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT,
+        name VARCHAR(100) NOT NULL,
+        channel VARCHAR(50) NOT NULL,
+        recipients TEXT NOT NULL,
+        description VARCHAR(255) NOT NULL DEFAULT '',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        UNIQUE KEY unique_name (name)
+    );
@@ -0,0 +1,5 @@
+ALTER TABLE notifications
+    DROP INDEX idx_dedup_key,
+    DROP INDEX idx_channel_recipient,
+    DROP COLUMN dedup_key,
+    DROP COLUMN recipient;
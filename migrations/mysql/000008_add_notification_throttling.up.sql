@@ -0,0 +1,5 @@
+ALTER TABLE notifications
+    ADD COLUMN recipient VARCHAR(255) NOT NULL DEFAULT '' AFTER channel,
+    ADD COLUMN dedup_key CHAR(64) NOT NULL DEFAULT '' AFTER message,
+    ADD INDEX idx_channel_recipient (channel, recipient, created_at),
+    ADD INDEX idx_dedup_key (dedup_key, created_at);
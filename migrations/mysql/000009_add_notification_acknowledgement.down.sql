@@ -0,0 +1,8 @@
+ALTER TABLE notifications
+    DROP INDEX idx_escalation,
+    DROP COLUMN escalated_at,
+    DROP COLUMN acked_by,
+    DROP COLUMN acked_at,
+    DROP COLUMN escalation_level,
+    DROP COLUMN severity,
+    DROP COLUMN group_id;
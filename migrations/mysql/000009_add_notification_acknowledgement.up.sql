@@ -0,0 +1,8 @@
+ALTER TABLE notifications
+    ADD COLUMN group_id BIGINT NULL AFTER record_id,
+    ADD COLUMN severity TINYINT NOT NULL DEFAULT 0 AFTER dedup_key,
+    ADD COLUMN escalation_level INT NOT NULL DEFAULT 0 AFTER severity,
+    ADD COLUMN acked_at TIMESTAMP NULL,
+    ADD COLUMN acked_by VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN escalated_at TIMESTAMP NULL,
+    ADD INDEX idx_escalation (acked_at, escalated_at, severity);
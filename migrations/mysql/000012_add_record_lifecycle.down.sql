@@ -0,0 +1,4 @@
+ALTER TABLE data_records
+    DROP INDEX idx_deleted_at,
+    DROP COLUMN deleted_at,
+    DROP COLUMN version;
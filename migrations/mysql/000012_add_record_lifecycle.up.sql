@@ -0,0 +1,4 @@
+ALTER TABLE data_records
+    ADD COLUMN version INT NOT NULL DEFAULT 1 AFTER metadata,
+    ADD COLUMN deleted_at TIMESTAMP NULL AFTER updated_at,
+    ADD INDEX idx_deleted_at (deleted_at);
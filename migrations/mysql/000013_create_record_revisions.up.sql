@@ -0,0 +1,12 @@
+CREATE TABLE
+    IF NOT EXISTS record_revisions (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT,
+        record_id BIGINT NOT NULL,
+        revision INT NOT NULL,
+        type VARCHAR(255) NOT NULL,
+        content TEXT NOT NULL,
+        metadata TEXT,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (record_id) REFERENCES data_records (id) ON DELETE CASCADE,
+        UNIQUE KEY unique_record_revision (record_id, revision)
+    );
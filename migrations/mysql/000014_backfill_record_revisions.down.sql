@@ -0,0 +1 @@
+DELETE FROM record_revisions WHERE revision = 1;
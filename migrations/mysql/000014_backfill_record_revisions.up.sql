@@ -0,0 +1,10 @@
+INSERT INTO
+    record_revisions (record_id, revision, type, content, metadata, created_at)
+SELECT
+    id, 1, type, content, metadata, updated_at
+FROM
+    data_records
+WHERE
+    NOT EXISTS (
+        SELECT 1 FROM record_revisions WHERE record_revisions.record_id = data_records.id
+    );
@@ -0,0 +1,3 @@
+ALTER TABLE analysis_results
+    DROP FOREIGN KEY fk_analysis_revision,
+    DROP COLUMN revision_id;
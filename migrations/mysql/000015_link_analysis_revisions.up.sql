@@ -0,0 +1,3 @@
+ALTER TABLE analysis_results
+    ADD COLUMN revision_id BIGINT NULL AFTER record_id,
+    ADD CONSTRAINT fk_analysis_revision FOREIGN KEY (revision_id) REFERENCES record_revisions (id) ON DELETE SET NULL;
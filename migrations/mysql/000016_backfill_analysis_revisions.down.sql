@@ -0,0 +1 @@
+UPDATE analysis_results SET revision_id = NULL;
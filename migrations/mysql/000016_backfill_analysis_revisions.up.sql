@@ -0,0 +1,7 @@
+UPDATE analysis_results a
+JOIN record_revisions r ON r.record_id = a.record_id
+AND r.revision = 1
+SET
+    a.revision_id = r.id
+WHERE
+    a.revision_id IS NULL;
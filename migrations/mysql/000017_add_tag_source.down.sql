@@ -0,0 +1,3 @@
+ALTER TABLE tags
+    DROP INDEX idx_tag_name,
+    DROP COLUMN source;
@@ -0,0 +1,3 @@
+ALTER TABLE tags
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'llm' AFTER tag_name,
+    ADD INDEX idx_tag_name (tag_name);
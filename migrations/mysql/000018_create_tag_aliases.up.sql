@@ -0,0 +1,7 @@
+CREATE TABLE
+    IF NOT EXISTS tag_aliases (
+        alias VARCHAR(255) PRIMARY KEY,
+        tag_name VARCHAR(255) NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        INDEX idx_tag_name (tag_name)
+    );
@@ -0,0 +1,6 @@
+CREATE TABLE
+    IF NOT EXISTS tag_vocabulary (
+        name VARCHAR(255) PRIMARY KEY,
+        description VARCHAR(255) NOT NULL DEFAULT '',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
@@ -0,0 +1,6 @@
+UPDATE data_records
+SET
+    metadata = JSON_UNQUOTE(JSON_EXTRACT(metadata, '$._raw'))
+WHERE
+    JSON_LENGTH(metadata) = 1
+    AND JSON_CONTAINS_PATH(metadata, 'one', '$._raw');
@@ -0,0 +1,8 @@
+UPDATE data_records
+SET
+    metadata = CASE
+        WHEN metadata IS NULL OR TRIM(metadata) = '' THEN '{}'
+        WHEN NOT JSON_VALID(metadata) THEN JSON_OBJECT('_raw', metadata)
+        WHEN JSON_TYPE(metadata) = 'OBJECT' THEN metadata
+        ELSE JSON_OBJECT('_raw', metadata)
+    END;
@@ -0,0 +1 @@
+ALTER TABLE data_records MODIFY metadata TEXT;
@@ -0,0 +1 @@
+ALTER TABLE record_revisions MODIFY metadata JSON NOT NULL;
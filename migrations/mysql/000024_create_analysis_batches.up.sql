@@ -0,0 +1,12 @@
+CREATE TABLE
+    IF NOT EXISTS analysis_batches (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT,
+        status VARCHAR(20) NOT NULL DEFAULT 'pending',
+        total INT NOT NULL DEFAULT 0,
+        done INT NOT NULL DEFAULT 0,
+        failed INT NOT NULL DEFAULT 0,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        started_at TIMESTAMP NULL,
+        finished_at TIMESTAMP NULL,
+        INDEX idx_status (status)
+    );
@@ -0,0 +1,13 @@
+CREATE TABLE
+    IF NOT EXISTS analysis_batch_items (
+        batch_id BIGINT NOT NULL,
+        record_id BIGINT NOT NULL,
+        status VARCHAR(20) NOT NULL DEFAULT 'pending',
+        result_id BIGINT NULL,
+        error TEXT,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (batch_id, record_id),
+        FOREIGN KEY (batch_id) REFERENCES analysis_batches (id) ON DELETE CASCADE,
+        FOREIGN KEY (result_id) REFERENCES analysis_results (id) ON DELETE SET NULL,
+        INDEX idx_batch_status (batch_id, status)
+    );
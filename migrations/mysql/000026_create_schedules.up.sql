@@ -0,0 +1,17 @@
+CREATE TABLE
+    IF NOT EXISTS schedules (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT,
+        name VARCHAR(100) NOT NULL,
+        cron_expr VARCHAR(100) NOT NULL,
+        mode VARCHAR(20) NOT NULL DEFAULT 'all',
+        filter JSON NOT NULL,
+        max_records INT NOT NULL DEFAULT 0,
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        next_run_at TIMESTAMP NULL,
+        last_run_at TIMESTAMP NULL,
+        last_batch_id BIGINT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (last_batch_id) REFERENCES analysis_batches (id) ON DELETE SET NULL,
+        INDEX idx_enabled_next_run (enabled, next_run_at)
+    );
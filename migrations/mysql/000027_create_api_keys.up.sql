@@ -0,0 +1,13 @@
+CREATE TABLE
+    IF NOT EXISTS api_keys (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT,
+        name VARCHAR(100) NOT NULL,
+        key_prefix VARCHAR(16) NOT NULL,
+        key_hash CHAR(64) NOT NULL,
+        role VARCHAR(20) NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_used_at TIMESTAMP NULL,
+        revoked_at TIMESTAMP NULL,
+        UNIQUE KEY unique_name (name),
+        UNIQUE KEY unique_key_hash (key_hash)
+    );
@@ -0,0 +1,3 @@
+ALTER TABLE data_records
+    DROP COLUMN updated_by,
+    DROP COLUMN created_by;
@@ -0,0 +1,3 @@
+ALTER TABLE data_records
+    ADD COLUMN created_by VARCHAR(100) NULL AFTER deleted_at,
+    ADD COLUMN updated_by VARCHAR(100) NULL AFTER created_by;
@@ -0,0 +1 @@
+ALTER TABLE analysis_results DROP COLUMN created_by;
@@ -0,0 +1 @@
+ALTER TABLE analysis_results ADD COLUMN created_by VARCHAR(100) NULL AFTER severity;
@@ -0,0 +1 @@
+ALTER TABLE analysis_batches DROP COLUMN created_by;
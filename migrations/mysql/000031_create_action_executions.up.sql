@@ -0,0 +1,17 @@
+CREATE TABLE
+    IF NOT EXISTS action_executions (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT,
+        record_id BIGINT NOT NULL,
+        analysis_id BIGINT NULL,
+        type VARCHAR(50) NOT NULL,
+        target VARCHAR(100) NOT NULL DEFAULT '',
+        params JSON NULL,
+        status VARCHAR(20) NOT NULL,
+        error TEXT,
+        executed_by VARCHAR(100) NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (record_id) REFERENCES data_records (id) ON DELETE CASCADE,
+        FOREIGN KEY (analysis_id) REFERENCES analysis_results (id) ON DELETE SET NULL,
+        INDEX idx_record_id (record_id),
+        INDEX idx_executed_by (executed_by)
+    );
@@ -0,0 +1,12 @@
+CREATE TABLE
+    IF NOT EXISTS tenants (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT,
+        slug VARCHAR(64) NOT NULL,
+        name VARCHAR(100) NOT NULL,
+        deepseek_api_key VARCHAR(255) NULL,
+        prompt_templates JSON NULL,
+        monthly_token_budget BIGINT NOT NULL DEFAULT 0,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        UNIQUE KEY unique_slug (slug)
+    );
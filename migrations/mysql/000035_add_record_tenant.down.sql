@@ -0,0 +1,3 @@
+ALTER TABLE data_records
+    DROP INDEX idx_tenant_created,
+    DROP COLUMN tenant_id;
@@ -0,0 +1,3 @@
+ALTER TABLE data_records
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 AFTER id,
+    ADD INDEX idx_tenant_created (tenant_id, created_at);
@@ -0,0 +1,3 @@
+ALTER TABLE analysis_results
+    DROP INDEX idx_tenant,
+    DROP COLUMN tenant_id;
@@ -0,0 +1,3 @@
+ALTER TABLE tags
+    DROP INDEX idx_tenant_tag_name,
+    DROP COLUMN tenant_id;
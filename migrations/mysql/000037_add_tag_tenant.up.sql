@@ -0,0 +1,3 @@
+ALTER TABLE tags
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 AFTER id,
+    ADD INDEX idx_tenant_tag_name (tenant_id, tag_name);
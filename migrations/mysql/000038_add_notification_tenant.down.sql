@@ -0,0 +1,3 @@
+ALTER TABLE notifications
+    DROP INDEX idx_tenant_status,
+    DROP COLUMN tenant_id;
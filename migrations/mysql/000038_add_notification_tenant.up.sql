@@ -0,0 +1,3 @@
+ALTER TABLE notifications
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 AFTER id,
+    ADD INDEX idx_tenant_status (tenant_id, status);
@@ -0,0 +1,3 @@
+ALTER TABLE api_keys
+    DROP INDEX idx_tenant,
+    DROP COLUMN tenant_id;
@@ -0,0 +1,3 @@
+ALTER TABLE notification_routes
+    DROP INDEX idx_tenant,
+    DROP COLUMN tenant_id;
@@ -0,0 +1,3 @@
+ALTER TABLE escalation_policies
+    DROP INDEX idx_tenant,
+    DROP COLUMN tenant_id;
@@ -0,0 +1,3 @@
+ALTER TABLE schedules
+    DROP INDEX idx_tenant,
+    DROP COLUMN tenant_id;
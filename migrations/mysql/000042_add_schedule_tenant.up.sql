@@ -0,0 +1,3 @@
+ALTER TABLE schedules
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 AFTER id,
+    ADD INDEX idx_tenant (tenant_id);
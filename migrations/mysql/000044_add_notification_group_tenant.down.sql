@@ -0,0 +1,4 @@
+ALTER TABLE notification_groups
+    DROP INDEX unique_tenant_name,
+    DROP COLUMN tenant_id,
+    ADD UNIQUE KEY unique_name (name);
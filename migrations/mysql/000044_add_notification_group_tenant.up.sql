@@ -0,0 +1,4 @@
+ALTER TABLE notification_groups
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 AFTER id,
+    DROP INDEX unique_name,
+    ADD UNIQUE KEY unique_tenant_name (tenant_id, name);
@@ -0,0 +1,4 @@
+ALTER TABLE tag_aliases
+    DROP PRIMARY KEY,
+    DROP COLUMN tenant_id,
+    ADD PRIMARY KEY (alias);
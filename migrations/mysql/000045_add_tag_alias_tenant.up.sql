@@ -0,0 +1,4 @@
+ALTER TABLE tag_aliases
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, alias);
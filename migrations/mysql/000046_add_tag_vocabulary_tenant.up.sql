@@ -0,0 +1,4 @@
+ALTER TABLE tag_vocabulary
+    ADD COLUMN tenant_id BIGINT NOT NULL DEFAULT 1 FIRST,
+    DROP PRIMARY KEY,
+    ADD PRIMARY KEY (tenant_id, name);
@@ -0,0 +1,7 @@
+CREATE TABLE
+    IF NOT EXISTS rate_limit_buckets (
+        bucket_key VARCHAR(191) NOT NULL PRIMARY KEY,
+        tokens DOUBLE NOT NULL,
+        updated_at DATETIME(6) NOT NULL,
+        INDEX idx_updated_at (updated_at)
+    );
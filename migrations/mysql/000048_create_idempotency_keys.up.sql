@@ -0,0 +1,13 @@
+CREATE TABLE
+    IF NOT EXISTS idempotency_keys (
+        scope VARCHAR(191) NOT NULL,
+        idempotency_key VARCHAR(191) NOT NULL,
+        request_hash CHAR(64) NOT NULL,
+        status_code INT NULL,
+        content_type VARCHAR(255) NULL,
+        response_body MEDIUMBLOB NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        expires_at TIMESTAMP NOT NULL,
+        PRIMARY KEY (scope, idempotency_key),
+        INDEX idx_expires_at (expires_at)
+    );
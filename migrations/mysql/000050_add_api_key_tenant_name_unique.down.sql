@@ -0,0 +1,3 @@
+ALTER TABLE api_keys
+    DROP INDEX unique_tenant_name,
+    ADD UNIQUE KEY unique_name (name);
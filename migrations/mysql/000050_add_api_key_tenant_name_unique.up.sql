@@ -0,0 +1,3 @@
+ALTER TABLE api_keys
+    DROP INDEX unique_name,
+    ADD UNIQUE KEY unique_tenant_name (tenant_id, name);
@@ -0,0 +1,11 @@
+CREATE TABLE
+    IF NOT EXISTS tenants (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        slug VARCHAR(64) NOT NULL UNIQUE,
+        name VARCHAR(100) NOT NULL,
+        deepseek_api_key VARCHAR(255) NULL,
+        prompt_templates JSONB NULL,
+        monthly_token_budget BIGINT NOT NULL DEFAULT 0,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
@@ -0,0 +1 @@
+DELETE FROM tenants WHERE id = 1;
@@ -0,0 +1,6 @@
+INSERT INTO
+    tenants (id, slug, name)
+VALUES
+    (1, 'default', '默认租户') ON CONFLICT DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('tenants', 'id'), (SELECT MAX(id) FROM tenants));
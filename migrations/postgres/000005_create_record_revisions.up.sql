@@ -0,0 +1,12 @@
+CREATE TABLE
+    IF NOT EXISTS record_revisions (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        record_id BIGINT NOT NULL,
+        revision INT NOT NULL,
+        type VARCHAR(255) NOT NULL,
+        content TEXT NOT NULL,
+        metadata JSONB NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (record_id) REFERENCES data_records (id) ON DELETE CASCADE,
+        UNIQUE (record_id, revision)
+    );
@@ -0,0 +1,17 @@
+CREATE TABLE
+    IF NOT EXISTS analysis_results (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        record_id BIGINT NOT NULL,
+        revision_id BIGINT NULL,
+        analysis TEXT NOT NULL,
+        suggestions TEXT NOT NULL,
+        confidence DOUBLE PRECISION NOT NULL,
+        severity SMALLINT NOT NULL DEFAULT 0,
+        created_by VARCHAR(100) NULL,
+        created_at TIMESTAMPTZ NOT NULL,
+        FOREIGN KEY (record_id) REFERENCES data_records (id),
+        FOREIGN KEY (revision_id) REFERENCES record_revisions (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS analysis_results_tenant ON analysis_results (tenant_id);
@@ -0,0 +1,12 @@
+CREATE TABLE
+    IF NOT EXISTS notification_groups (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        name VARCHAR(100) NOT NULL,
+        channel VARCHAR(50) NOT NULL,
+        recipients TEXT NOT NULL,
+        description VARCHAR(255) NOT NULL DEFAULT '',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        UNIQUE (tenant_id, name)
+    );
@@ -0,0 +1,32 @@
+CREATE TABLE
+    IF NOT EXISTS notifications (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        record_id BIGINT NOT NULL,
+        group_id BIGINT NULL,
+        channel VARCHAR(50) NOT NULL,
+        recipient VARCHAR(255) NOT NULL DEFAULT '',
+        message TEXT NOT NULL,
+        dedup_key VARCHAR(64) NOT NULL DEFAULT '',
+        severity SMALLINT NOT NULL DEFAULT 0,
+        escalation_level INT NOT NULL DEFAULT 0,
+        status VARCHAR(20) NOT NULL DEFAULT 'pending',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        sent_at TIMESTAMPTZ NULL,
+        acked_at TIMESTAMPTZ NULL,
+        acked_by VARCHAR(255) NOT NULL DEFAULT '',
+        escalated_at TIMESTAMPTZ NULL,
+        FOREIGN KEY (record_id) REFERENCES data_records (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS notifications_status ON notifications (status);
+
+CREATE INDEX IF NOT EXISTS notifications_created_at ON notifications (created_at);
+
+CREATE INDEX IF NOT EXISTS notifications_channel_recipient ON notifications (channel, recipient, created_at);
+
+CREATE INDEX IF NOT EXISTS notifications_dedup_key ON notifications (dedup_key, created_at);
+
+CREATE INDEX IF NOT EXISTS notifications_escalation ON notifications (acked_at, escalated_at, severity);
+
+CREATE INDEX IF NOT EXISTS notifications_tenant_status ON notifications (tenant_id, status);
@@ -0,0 +1,14 @@
+CREATE TABLE
+    IF NOT EXISTS notification_escalations (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        notification_id BIGINT NOT NULL,
+        escalated_notification_id BIGINT NOT NULL,
+        policy_id BIGINT NOT NULL,
+        level INT NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (notification_id) REFERENCES notifications (id) ON DELETE CASCADE,
+        FOREIGN KEY (escalated_notification_id) REFERENCES notifications (id) ON DELETE CASCADE,
+        FOREIGN KEY (policy_id) REFERENCES escalation_policies (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS notification_escalations_escalated ON notification_escalations (escalated_notification_id);
@@ -0,0 +1,17 @@
+CREATE TABLE
+    IF NOT EXISTS analysis_batches (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        status VARCHAR(20) NOT NULL DEFAULT 'pending',
+        total INT NOT NULL DEFAULT 0,
+        done INT NOT NULL DEFAULT 0,
+        failed INT NOT NULL DEFAULT 0,
+        created_by VARCHAR(100) NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        started_at TIMESTAMPTZ NULL,
+        finished_at TIMESTAMPTZ NULL
+    );
+
+CREATE INDEX IF NOT EXISTS analysis_batches_status ON analysis_batches (status);
+
+CREATE INDEX IF NOT EXISTS analysis_batches_tenant ON analysis_batches (tenant_id);
@@ -0,0 +1,14 @@
+CREATE TABLE
+    IF NOT EXISTS api_keys (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        name VARCHAR(100) NOT NULL UNIQUE,
+        key_prefix VARCHAR(16) NOT NULL,
+        key_hash VARCHAR(64) NOT NULL UNIQUE,
+        role VARCHAR(20) NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_used_at TIMESTAMPTZ NULL,
+        revoked_at TIMESTAMPTZ NULL
+    );
+
+CREATE INDEX IF NOT EXISTS api_keys_tenant ON api_keys (tenant_id);
@@ -0,0 +1,19 @@
+CREATE TABLE
+    IF NOT EXISTS action_executions (
+        id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+        record_id BIGINT NOT NULL,
+        analysis_id BIGINT NULL,
+        type VARCHAR(50) NOT NULL,
+        target VARCHAR(100) NOT NULL DEFAULT '',
+        params JSONB NULL,
+        status VARCHAR(20) NOT NULL,
+        error TEXT,
+        executed_by VARCHAR(100) NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (record_id) REFERENCES data_records (id) ON DELETE CASCADE,
+        FOREIGN KEY (analysis_id) REFERENCES analysis_results (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS action_executions_record_id ON action_executions (record_id);
+
+CREATE INDEX IF NOT EXISTS action_executions_executed_by ON action_executions (executed_by);
@@ -0,0 +1,3 @@
+ALTER TABLE analysis_batches
+    ADD COLUMN owner VARCHAR(100) NULL,
+    ADD COLUMN heartbeat_at TIMESTAMPTZ NULL;
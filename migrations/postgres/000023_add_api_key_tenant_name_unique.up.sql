@@ -0,0 +1,3 @@
+ALTER TABLE api_keys
+    DROP CONSTRAINT api_keys_name_key,
+    ADD CONSTRAINT api_keys_tenant_name_key UNIQUE (tenant_id, name);
@@ -0,0 +1,4 @@
+INSERT OR IGNORE INTO
+    tenants (id, slug, name)
+VALUES
+    (1, 'default', '默认租户');
@@ -0,0 +1,10 @@
+CREATE TABLE
+    IF NOT EXISTS tenant_usage (
+        tenant_id BIGINT NOT NULL,
+        month CHAR(7) NOT NULL,
+        requests INT NOT NULL DEFAULT 0,
+        tokens BIGINT NOT NULL DEFAULT 0,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (tenant_id, month),
+        FOREIGN KEY (tenant_id) REFERENCES tenants (id) ON DELETE CASCADE
+    );
@@ -0,0 +1,18 @@
+CREATE TABLE
+    IF NOT EXISTS data_records (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        type VARCHAR(255) NOT NULL,
+        content TEXT NOT NULL,
+        metadata TEXT NOT NULL,
+        version INT NOT NULL DEFAULT 1,
+        created_at TIMESTAMP NOT NULL,
+        updated_at TIMESTAMP NOT NULL,
+        deleted_at TIMESTAMP NULL,
+        created_by VARCHAR(100) NULL,
+        updated_by VARCHAR(100) NULL
+    );
+
+CREATE INDEX IF NOT EXISTS data_records_deleted_at ON data_records (deleted_at);
+
+CREATE INDEX IF NOT EXISTS data_records_tenant_created ON data_records (tenant_id, created_at);
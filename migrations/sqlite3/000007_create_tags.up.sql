@@ -0,0 +1,17 @@
+CREATE TABLE
+    IF NOT EXISTS tags (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        record_id BIGINT NOT NULL,
+        tag_name VARCHAR(255) NOT NULL,
+        source VARCHAR(20) NOT NULL DEFAULT 'llm',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (record_id) REFERENCES data_records (id) ON DELETE CASCADE,
+        UNIQUE (record_id, tag_name)
+    );
+
+CREATE INDEX IF NOT EXISTS tags_created_at ON tags (created_at);
+
+CREATE INDEX IF NOT EXISTS tags_tag_name ON tags (tag_name);
+
+CREATE INDEX IF NOT EXISTS tags_tenant_tag_name ON tags (tenant_id, tag_name);
@@ -0,0 +1,10 @@
+CREATE TABLE
+    IF NOT EXISTS tag_aliases (
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        alias VARCHAR(255) NOT NULL,
+        tag_name VARCHAR(255) NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (tenant_id, alias)
+    );
+
+CREATE INDEX IF NOT EXISTS tag_aliases_tag_name ON tag_aliases (tag_name);
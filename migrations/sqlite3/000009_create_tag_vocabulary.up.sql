@@ -0,0 +1,8 @@
+CREATE TABLE
+    IF NOT EXISTS tag_vocabulary (
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        name VARCHAR(255) NOT NULL,
+        description VARCHAR(255) NOT NULL DEFAULT '',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (tenant_id, name)
+    );
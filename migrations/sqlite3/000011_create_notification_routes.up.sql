@@ -0,0 +1,19 @@
+CREATE TABLE
+    IF NOT EXISTS notification_routes (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        group_id BIGINT NOT NULL,
+        record_type VARCHAR(255) NOT NULL DEFAULT '',
+        tag VARCHAR(255) NOT NULL DEFAULT '',
+        min_severity TINYINT NOT NULL DEFAULT 0,
+        start_hour TINYINT NOT NULL DEFAULT 0,
+        end_hour TINYINT NOT NULL DEFAULT 24,
+        priority INT NOT NULL DEFAULT 0,
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (group_id) REFERENCES notification_groups (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS notification_routes_enabled_priority ON notification_routes (enabled, priority);
+
+CREATE INDEX IF NOT EXISTS notification_routes_tenant ON notification_routes (tenant_id);
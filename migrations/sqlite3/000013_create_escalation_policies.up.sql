@@ -0,0 +1,17 @@
+CREATE TABLE
+    IF NOT EXISTS escalation_policies (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        name VARCHAR(100) NOT NULL,
+        source_group_id BIGINT NULL,
+        target_group_id BIGINT NOT NULL,
+        level INT NOT NULL DEFAULT 1,
+        min_severity TINYINT NOT NULL DEFAULT 4,
+        ack_timeout_minutes INT NOT NULL DEFAULT 15,
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (source_group_id) REFERENCES notification_groups (id) ON DELETE CASCADE,
+        FOREIGN KEY (target_group_id) REFERENCES notification_groups (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS escalation_policies_tenant ON escalation_policies (tenant_id);
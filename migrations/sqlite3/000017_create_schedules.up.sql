@@ -0,0 +1,21 @@
+CREATE TABLE
+    IF NOT EXISTS schedules (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        name VARCHAR(100) NOT NULL,
+        cron_expr VARCHAR(100) NOT NULL,
+        mode VARCHAR(20) NOT NULL DEFAULT 'all',
+        filter TEXT NOT NULL,
+        max_records INT NOT NULL DEFAULT 0,
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        next_run_at TIMESTAMP NULL,
+        last_run_at TIMESTAMP NULL,
+        last_batch_id BIGINT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (last_batch_id) REFERENCES analysis_batches (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS schedules_enabled_next_run ON schedules (enabled, next_run_at);
+
+CREATE INDEX IF NOT EXISTS schedules_tenant ON schedules (tenant_id);
@@ -0,0 +1,8 @@
+CREATE TABLE
+    IF NOT EXISTS rate_limit_buckets (
+        bucket_key VARCHAR(191) NOT NULL PRIMARY KEY,
+        tokens DOUBLE NOT NULL,
+        updated_at DATETIME NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
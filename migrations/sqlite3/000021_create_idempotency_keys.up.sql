@@ -0,0 +1,14 @@
+CREATE TABLE
+    IF NOT EXISTS idempotency_keys (
+        scope VARCHAR(191) NOT NULL,
+        idempotency_key VARCHAR(191) NOT NULL,
+        request_hash CHAR(64) NOT NULL,
+        status_code INT NULL,
+        content_type VARCHAR(255) NULL,
+        response_body BLOB NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        expires_at TIMESTAMP NOT NULL,
+        PRIMARY KEY (scope, idempotency_key)
+    );
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at ON idempotency_keys (expires_at);
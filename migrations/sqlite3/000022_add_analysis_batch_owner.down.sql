@@ -0,0 +1,3 @@
+ALTER TABLE analysis_batches DROP COLUMN heartbeat_at;
+
+ALTER TABLE analysis_batches DROP COLUMN owner;
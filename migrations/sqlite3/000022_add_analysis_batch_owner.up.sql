@@ -0,0 +1,3 @@
+ALTER TABLE analysis_batches ADD COLUMN owner VARCHAR(100) NULL;
+
+ALTER TABLE analysis_batches ADD COLUMN heartbeat_at TIMESTAMP NULL;
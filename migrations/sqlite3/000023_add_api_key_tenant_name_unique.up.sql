@@ -0,0 +1,22 @@
+CREATE TABLE
+    api_keys_new (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        tenant_id BIGINT NOT NULL DEFAULT 1,
+        name VARCHAR(100) NOT NULL,
+        key_prefix VARCHAR(16) NOT NULL,
+        key_hash CHAR(64) NOT NULL UNIQUE,
+        role VARCHAR(20) NOT NULL,
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_used_at TIMESTAMP NULL,
+        revoked_at TIMESTAMP NULL,
+        UNIQUE (tenant_id, name)
+    );
+
+INSERT INTO api_keys_new (id, tenant_id, name, key_prefix, key_hash, role, created_at, last_used_at, revoked_at)
+SELECT id, tenant_id, name, key_prefix, key_hash, role, created_at, last_used_at, revoked_at FROM api_keys;
+
+DROP TABLE api_keys;
+
+ALTER TABLE api_keys_new RENAME TO api_keys;
+
+CREATE INDEX IF NOT EXISTS api_keys_tenant ON api_keys (tenant_id);
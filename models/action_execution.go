@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// 操作执行状态
+const (
+	ActionSucceeded = "succeeded"
+	ActionFailed    = "failed"
+)
+
+// ActionExecution 分析建议的操作的执行记录
+type ActionExecution struct {
+	ID         int64                  `json:"id"`
+	RecordID   int64                  `json:"recordId"`
+	AnalysisID int64                  `json:"analysisId,omitempty"`
+	Type       string                 `json:"type"`
+	Target     string                 `json:"target"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error,omitempty"`
+	ExecutedBy string                 `json:"executedBy,omitempty"` // 发起分析的调用方
+	CreatedAt  time.Time              `json:"createdAt"`
+}
+
+// CreateActionExecution 保存操作执行记录
+func CreateActionExecution(db *sql.DB, execution *ActionExecution) error {
+	params, err := json.Marshal(execution.Params)
+	if err != nil {
+		return fmt.Errorf("error encoding action params: %v", err)
+	}
+
+	execution.CreatedAt = time.Now()
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO action_executions (record_id, analysis_id, type, target, params, status, error, executed_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		execution.RecordID, nullableID(execution.AnalysisID), execution.Type, execution.Target, string(params),
+		execution.Status, nullableString(execution.Error), nullableString(execution.ExecutedBy), execution.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving action execution: %v", err)
+	}
+	execution.ID = id
+	return nil
+}
+
+// GetActionExecutions 获取租户下记录的操作执行历史，按时间升序
+func GetActionExecutions(db *sql.DB, tenantID int64, recordID int64) ([]ActionExecution, error) {
+	rows, err := db.Query(
+		`SELECT id, record_id, COALESCE(analysis_id, 0), type, target, params, status, COALESCE(error, ''),
+		COALESCE(executed_by, ''), created_at FROM action_executions
+		WHERE record_id = ? AND record_id IN (SELECT id FROM data_records WHERE tenant_id = ?) ORDER BY id`,
+		recordID, tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing action executions: %v", err)
+	}
+	defer rows.Close()
+
+	executions := []ActionExecution{}
+	for rows.Next() {
+		var execution ActionExecution
+		var params []byte
+		if err := rows.Scan(&execution.ID, &execution.RecordID, &execution.AnalysisID, &execution.Type,
+			&execution.Target, &params, &execution.Status, &execution.Error, &execution.ExecutedBy,
+			&execution.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning action execution: %v", err)
+		}
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &execution.Params); err != nil {
+				return nil, fmt.Errorf("error decoding action params: %v", err)
+			}
+		}
+		executions = append(executions, execution)
+	}
+	return executions, rows.Err()
+}
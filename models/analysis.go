@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 )
 
@@ -57,110 +58,259 @@ type Action struct {
 	Rollback string                 `json:"rollback,omitempty"`
 }
 
-// Tag 数据标签
-type Tag struct {
-	ID        int64     `json:"id"`
-	RecordID  int64     `json:"record_id"`
-	TagName   string    `json:"tag_name"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
 // Notification 通知记录
 type Notification struct {
-	ID        int64     `json:"id"`
-	RecordID  int64     `json:"record_id"`
-	Channel   string    `json:"channel"`
-	Message   string    `json:"message"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	SentAt    time.Time `json:"sent_at,omitempty"`
-}
-
-// UpdateStatus 更新数据记录状态
-func UpdateStatus(db *sql.DB, id string, status string) error {
-	_, err := db.Exec("UPDATE data_records SET metadata = JSON_SET(COALESCE(metadata, '{}'), '$.status', ?) WHERE id = ?", status, id)
-	return err
+	ID              int64      `json:"id"`
+	TenantID        int64      `json:"-"`
+	RecordID        int64      `json:"record_id"`
+	GroupID         int64      `json:"group_id,omitempty"` // 接收组ID，未经路由的通知为 0
+	Channel         string     `json:"channel"`
+	Recipient       string     `json:"recipient"`
+	Message         string     `json:"message"`
+	DedupKey        string     `json:"dedup_key"`
+	Severity        int        `json:"severity"`
+	EscalationLevel int        `json:"escalation_level"` // 升级层级，原始通知为 0
+	Status          string     `json:"status"`
+	CreatedAt       time.Time  `json:"created_at"`
+	SentAt          time.Time  `json:"sent_at,omitempty"`
+	AckedAt         *time.Time `json:"acked_at,omitempty"`
+	AckedBy         string     `json:"acked_by,omitempty"`
+	EscalatedAt     *time.Time `json:"escalated_at,omitempty"`
 }
 
-// AddTag 添加标签
-func AddTag(db *sql.DB, recordID string, tagName string) error {
+// 通知状态
+const (
+	NotificationPending    = "pending"
+	NotificationSent       = "sent"
+	NotificationFailed     = "failed"
+	NotificationSuppressed = "suppressed" // 因重复或频率限制未发送，等待汇总
+	NotificationDigested   = "digested"   // 已合并到汇总通知中发送
+)
+
+// UpdateStatus 更新租户数据记录的状态
+func UpdateStatus(db *sql.DB, tenantID int64, id string, status string) error {
+	dialect := DialectOf(db)
 	_, err := db.Exec(
-		"INSERT INTO tags (record_id, tag_name, created_at) VALUES (?, ?, ?)",
-		recordID, tagName, time.Now(),
+		`UPDATE data_records SET metadata = `+dialect.jsonSetText("metadata", "?", "?")+`,
+		version = version + 1, updated_at = ? WHERE id = ? AND tenant_id = ?`,
+		dialect.jsonPath("$.status"), status, time.Now(), id, tenantID,
 	)
 	return err
 }
 
-// CreateNotification 创建通知
-func CreateNotification(db *sql.DB, recordID int64, channel string, message string) error {
-	_, err := db.Exec(
-		"INSERT INTO notifications (record_id, channel, message, status, created_at) VALUES (?, ?, ?, ?, ?)",
-		recordID, channel, message, "pending", time.Now(),
+// CreateNotification 创建通知，未指定状态时为 pending
+func CreateNotification(db *sql.DB, notification *Notification) error {
+	if notification.Status == "" {
+		notification.Status = NotificationPending
+	}
+	notification.CreatedAt = time.Now()
+
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO notifications (tenant_id, record_id, group_id, channel, recipient, message, dedup_key, severity,
+		escalation_level, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		notification.TenantID, notification.RecordID, nullableID(notification.GroupID), notification.Channel, notification.Recipient, notification.Message,
+		notification.DedupKey, notification.Severity, notification.EscalationLevel, notification.Status,
+		notification.CreatedAt,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	notification.ID = id
+	return nil
 }
 
-// UpdateNotificationStatus 更新通知状态
-func UpdateNotificationStatus(db *sql.DB, id int64, status string) error {
+// UpdateNotificationStatus 更新租户通知的状态
+func UpdateNotificationStatus(db *sql.DB, tenantID int64, id int64, status string) error {
 	var sentAt interface{}
-	if status == "sent" {
+	if status == NotificationSent || status == NotificationDigested {
 		sentAt = time.Now()
 	}
 	_, err := db.Exec(
-		"UPDATE notifications SET status = ?, sent_at = ? WHERE id = ?",
-		status, sentAt, id,
+		"UPDATE notifications SET status = ?, sent_at = ? WHERE id = ? AND tenant_id = ?",
+		status, sentAt, id, tenantID,
 	)
 	return err
 }
 
-// GetTagsByRecordID 获取记录的所有标签
-func GetTagsByRecordID(db *sql.DB, recordID int64) ([]Tag, error) {
-	rows, err := db.Query("SELECT id, record_id, tag_name, created_at FROM tags WHERE record_id = ?", recordID)
+// GetPendingNotifications 获取租户待处理的通知
+func GetPendingNotifications(db *sql.DB, tenantID int64) ([]Notification, error) {
+	return queryNotifications(db, "WHERE tenant_id = ? AND status = ?", tenantID, NotificationPending)
+}
+
+// GetSuppressedNotifications 获取租户因重复或频率限制未发送、等待汇总的通知
+func GetSuppressedNotifications(db *sql.DB, tenantID int64) ([]Notification, error) {
+	return queryNotifications(db, "WHERE tenant_id = ? AND status = ? ORDER BY channel, recipient, created_at",
+		tenantID, NotificationSuppressed)
+}
+
+// CountSentNotifications 统计租户自 since 以来向某渠道接收人发送的通知数量
+func CountSentNotifications(db *sql.DB, tenantID int64, channel string, recipient string, since time.Time) (int, error) {
+	var count int
+	err := db.QueryRow(
+		`SELECT COUNT(*) FROM notifications
+		WHERE tenant_id = ? AND channel = ? AND recipient = ? AND status = ? AND created_at >= ?`,
+		tenantID, channel, recipient, NotificationSent, since,
+	).Scan(&count)
+	return count, err
+}
+
+// HasRecentNotification 判断租户自 since 以来是否已发送过相同去重键的通知
+func HasRecentNotification(db *sql.DB, tenantID int64, dedupKey string, since time.Time) (bool, error) {
+	var exists bool
+	err := db.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM notifications
+		WHERE tenant_id = ? AND dedup_key = ? AND status = ? AND created_at >= ?)`,
+		tenantID, dedupKey, NotificationSent, since,
+	).Scan(&exists)
+	return exists, err
+}
+
+// MarkNotificationsDigested 将租户仍处于 suppressed 状态的通知标记为已汇总，返回实际更新的数量
+func MarkNotificationsDigested(db *sql.DB, tenantID int64, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	args := []interface{}{NotificationDigested, time.Now(), tenantID}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, NotificationSuppressed)
+
+	result, err := db.Exec(
+		"UPDATE notifications SET status = ?, sent_at = ? WHERE tenant_id = ? AND id IN ("+placeholders+") AND status = ?",
+		args...,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// queryNotifications 按条件查询通知
+func queryNotifications(db *sql.DB, where string, args ...interface{}) ([]Notification, error) {
+	rows, err := db.Query(notificationColumns+" FROM notifications "+where, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var tags []Tag
+	var notifications []Notification
 	for rows.Next() {
-		var tag Tag
-		if err := rows.Scan(&tag.ID, &tag.RecordID, &tag.TagName, &tag.CreatedAt); err != nil {
+		notification, err := scanNotification(rows)
+		if err != nil {
 			return nil, err
 		}
-		tags = append(tags, tag)
+		notifications = append(notifications, *notification)
 	}
-	return tags, nil
+	return notifications, nil
 }
 
-// GetPendingNotifications 获取待处理的通知
-func GetPendingNotifications(db *sql.DB) ([]Notification, error) {
-	rows, err := db.Query(
-		"SELECT id, record_id, channel, message, status, created_at, sent_at FROM notifications WHERE status = 'pending'",
-	)
-	if err != nil {
+const notificationColumns = `SELECT id, tenant_id, record_id, group_id, channel, recipient, message, dedup_key, severity,
+	escalation_level, status, created_at, sent_at, acked_at, acked_by, escalated_at`
+
+func scanNotification(row interface{ Scan(...interface{}) error }) (*Notification, error) {
+	var notification Notification
+	var groupID sql.NullInt64
+	var sentAt, ackedAt, escalatedAt sql.NullTime
+	if err := row.Scan(
+		&notification.ID,
+		&notification.TenantID,
+		&notification.RecordID,
+		&groupID,
+		&notification.Channel,
+		&notification.Recipient,
+		&notification.Message,
+		&notification.DedupKey,
+		&notification.Severity,
+		&notification.EscalationLevel,
+		&notification.Status,
+		&notification.CreatedAt,
+		&sentAt,
+		&ackedAt,
+		&notification.AckedBy,
+		&escalatedAt,
+	); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	notification.GroupID = groupID.Int64
+	if sentAt.Valid {
+		notification.SentAt = sentAt.Time
+	}
+	if ackedAt.Valid {
+		notification.AckedAt = &ackedAt.Time
+	}
+	if escalatedAt.Valid {
+		notification.EscalatedAt = &escalatedAt.Time
+	}
+	return &notification, nil
+}
 
-	var notifications []Notification
-	for rows.Next() {
-		var notification Notification
-		var sentAt sql.NullTime
-		if err := rows.Scan(
-			&notification.ID,
-			&notification.RecordID,
-			&notification.Channel,
-			&notification.Message,
-			&notification.Status,
-			&notification.CreatedAt,
-			&sentAt,
+// GetNotification 获取租户的通知，不存在或属于其他租户时返回 nil
+func GetNotification(db *sql.DB, tenantID int64, id int64) (*Notification, error) {
+	notification, err := scanNotification(db.QueryRow(
+		notificationColumns+" FROM notifications WHERE id = ? AND tenant_id = ?", id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return notification, err
+}
+
+// GetNotificationTenantID 获取通知所属的租户，通知不存在时返回 0。
+// 仅用于已通过签名校验、不携带 API Key 的确认链接
+func GetNotificationTenantID(db *sql.DB, id int64) (int64, error) {
+	var tenantID int64
+	err := db.QueryRow("SELECT tenant_id FROM notifications WHERE id = ?", id).Scan(&tenantID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return tenantID, err
+}
+
+// AcknowledgeNotification 确认租户的通知，同时确认其升级链上的所有上游通知，使升级不再继续
+func AcknowledgeNotification(db *sql.DB, tenantID int64, id int64, by string) error {
+	now := time.Now()
+	for id != 0 {
+		if _, err := db.Exec(
+			"UPDATE notifications SET acked_at = ?, acked_by = ? WHERE id = ? AND tenant_id = ? AND acked_at IS NULL",
+			now, by, id, tenantID,
 		); err != nil {
-			return nil, err
+			return err
 		}
-		if sentAt.Valid {
-			notification.SentAt = sentAt.Time
+
+		var parentID int64
+		err := db.QueryRow(
+			"SELECT notification_id FROM notification_escalations WHERE escalated_notification_id = ?", id,
+		).Scan(&parentID)
+		if err != nil && err != sql.ErrNoRows {
+			return err
 		}
-		notifications = append(notifications, notification)
+		id = parentID
 	}
-	return notifications, nil
+	return nil
+}
+
+// GetUnacknowledgedNotifications 获取租户在 before 之前发出、尚未确认且尚未升级的通知
+func GetUnacknowledgedNotifications(db *sql.DB, tenantID int64, minSeverity int, level int, before time.Time) ([]Notification, error) {
+	return queryNotifications(db,
+		`WHERE tenant_id = ? AND status IN (?, ?) AND acked_at IS NULL AND escalated_at IS NULL
+		AND severity >= ? AND escalation_level = ? AND created_at <= ?`,
+		tenantID, NotificationSent, NotificationFailed, minSeverity, level, before,
+	)
+}
+
+// MarkNotificationEscalated 标记租户的通知已升级，返回是否由本次调用完成标记
+func MarkNotificationEscalated(db *sql.DB, tenantID int64, id int64) (bool, error) {
+	result, err := db.Exec(
+		`UPDATE notifications SET escalated_at = ?
+		WHERE id = ? AND tenant_id = ? AND escalated_at IS NULL AND acked_at IS NULL`,
+		time.Now(), id, tenantID,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
 }
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AnalysisDiff 两次分析结果的差异
+type AnalysisDiff struct {
+	From               *AnalysisResult `json:"from"`
+	To                 *AnalysisResult `json:"to"`
+	RevisionChanged    bool            `json:"revisionChanged"`    // 两次分析的记录内容版本是否不同
+	AnalysisChanged    bool            `json:"analysisChanged"`    // 分析结果文本是否不同
+	ConfidenceChange   float64         `json:"confidenceChange"`   // 置信度变化量 (to - from)
+	SeverityChanged    bool            `json:"severityChanged"`    // 严重程度是否变化
+	SeverityChange     int             `json:"severityChange"`     // 严重程度变化量 (to - from)
+	AddedSuggestions   []string        `json:"addedSuggestions"`   // 新增的建议
+	RemovedSuggestions []string        `json:"removedSuggestions"` // 移除的建议
+}
+
+const analysisColumns = `SELECT a.id, a.tenant_id, a.record_id, COALESCE(a.revision_id, 0), COALESCE(r.revision, 0), a.analysis,
+	a.suggestions, a.confidence, a.severity, COALESCE(a.created_by, ''), a.created_at
+	FROM analysis_results a LEFT JOIN record_revisions r ON r.id = a.revision_id`
+
+// GetAnalysisResults 获取租户记录的全部分析历史，按时间升序
+func GetAnalysisResults(db *sql.DB, tenantID int64, recordID int64) ([]AnalysisResult, error) {
+	rows, err := db.Query(analysisColumns+" WHERE a.record_id = ? AND a.tenant_id = ? ORDER BY a.id", recordID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing analysis results: %v", err)
+	}
+	defer rows.Close()
+
+	results := []AnalysisResult{}
+	for rows.Next() {
+		result, err := scanAnalysisResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *result)
+	}
+	return results, rows.Err()
+}
+
+// GetAnalysisResult 获取租户的分析结果，不存在或属于其他租户时返回 nil
+func GetAnalysisResult(db *sql.DB, tenantID int64, id int64) (*AnalysisResult, error) {
+	result, err := scanAnalysisResult(db.QueryRow(analysisColumns+" WHERE a.id = ? AND a.tenant_id = ?", id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return result, err
+}
+
+func scanAnalysisResult(row interface{ Scan(...interface{}) error }) (*AnalysisResult, error) {
+	var result AnalysisResult
+	var suggestions string
+	if err := row.Scan(&result.ID, &result.TenantID, &result.RecordID, &result.RevisionID, &result.Revision, &result.Analysis,
+		&suggestions, &result.Confidence, &result.Severity, &result.CreatedBy, &result.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error scanning analysis result: %v", err)
+	}
+	result.Suggestions = decodeSuggestions(suggestions)
+	return &result, nil
+}
+
+// decodeSuggestions 解析建议列表。早期版本以 fmt 的 "[a b]" 格式保存，无法可靠拆分，整体作为一条建议返回
+func decodeSuggestions(value string) []string {
+	var suggestions []string
+	if err := json.Unmarshal([]byte(value), &suggestions); err == nil {
+		return suggestions
+	}
+
+	legacy := strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	if legacy == "" {
+		return []string{}
+	}
+	return []string{legacy}
+}
+
+// DiffAnalyses 比较两次分析结果
+func DiffAnalyses(from *AnalysisResult, to *AnalysisResult) *AnalysisDiff {
+	diff := &AnalysisDiff{
+		From:               from,
+		To:                 to,
+		RevisionChanged:    from.RevisionID != to.RevisionID,
+		AnalysisChanged:    from.Analysis != to.Analysis,
+		ConfidenceChange:   to.Confidence - from.Confidence,
+		SeverityChanged:    from.Severity != to.Severity,
+		SeverityChange:     to.Severity - from.Severity,
+		AddedSuggestions:   []string{},
+		RemovedSuggestions: []string{},
+	}
+
+	before := make(map[string]bool, len(from.Suggestions))
+	for _, suggestion := range from.Suggestions {
+		before[suggestion] = true
+	}
+	after := make(map[string]bool, len(to.Suggestions))
+	for _, suggestion := range to.Suggestions {
+		after[suggestion] = true
+		if !before[suggestion] {
+			diff.AddedSuggestions = append(diff.AddedSuggestions, suggestion)
+		}
+	}
+	for _, suggestion := range from.Suggestions {
+		if !after[suggestion] {
+			diff.RemovedSuggestions = append(diff.RemovedSuggestions, suggestion)
+		}
+	}
+	return diff
+}
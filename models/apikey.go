@@ -0,0 +1,153 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// 角色，权限依次递增，高级角色拥有低级角色的全部权限
+const (
+	RoleReader   = "reader"   // 只读访问
+	RoleAnalyst  = "analyst"  // 管理记录和标签，发起分析
+	RoleApprover = "approver" // 确认通知，管理定时规则和标签词表
+	RoleAdmin    = "admin"    // 管理通知接收组、路由和升级策略
+)
+
+// roleRanks 角色等级
+var roleRanks = map[string]int{
+	RoleReader:   1,
+	RoleAnalyst:  2,
+	RoleApprover: 3,
+	RoleAdmin:    4,
+}
+
+// apiKeyPrefix API Key 的固定前缀，便于在日志和代码中识别
+const apiKeyPrefix = "dsk_"
+
+// IsValidRole 判断是否为支持的角色
+func IsValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// RoleAllows 判断角色是否拥有 required 角色的权限
+func RoleAllows(role string, required string) bool {
+	return roleRanks[role] >= roleRanks[required]
+}
+
+// APIKey API 访问密钥，数据库中只保存 SHA-256 哈希
+type APIKey struct {
+	ID         int64      `json:"id"`
+	TenantID   int64      `json:"tenantId"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // 密钥的前几位，用于辨认
+	Role       string     `json:"role"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// hashAPIKey 计算密钥的哈希。密钥为 32 字节随机数，无需加盐或慢哈希
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey 为租户生成并保存新的 API Key，返回的明文密钥只在创建时可见
+func CreateAPIKey(db *sql.DB, tenantID int64, name string, role string) (*APIKey, string, error) {
+	if !IsValidRole(role) {
+		return nil, "", fmt.Errorf("invalid role: %s", role)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("error generating api key: %v", err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+
+	apiKey := &APIKey{
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    key[:len(apiKeyPrefix)+8],
+		Role:      role,
+		CreatedAt: time.Now(),
+	}
+	id, err := DialectOf(db).insertID(db,
+		"INSERT INTO api_keys (tenant_id, name, key_prefix, key_hash, role, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		apiKey.TenantID, apiKey.Name, apiKey.Prefix, hashAPIKey(key), apiKey.Role, apiKey.CreatedAt,
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating api key: %v", err)
+	}
+	apiKey.ID = id
+	return apiKey, key, nil
+}
+
+// AuthenticateAPIKey 根据明文密钥查找未吊销的 API Key，不存在时返回 nil
+func AuthenticateAPIKey(db *sql.DB, key string) (*APIKey, error) {
+	keys, err := queryAPIKeys(db, "WHERE key_hash = ? AND revoked_at IS NULL", hashAPIKey(key))
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+	apiKey := &keys[0]
+
+	// 最近使用时间精确到分钟即可，避免每个请求都写数据库
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > time.Minute {
+		if _, err := db.Exec("UPDATE api_keys SET last_used_at = ? WHERE id = ?", now, apiKey.ID); err != nil {
+			return nil, fmt.Errorf("error updating api key usage: %v", err)
+		}
+		apiKey.LastUsedAt = &now
+	}
+	return apiKey, nil
+}
+
+// RevokeAPIKey 吊销租户下指定名称的 API Key，名称只在租户内唯一，返回是否找到未吊销的密钥
+func RevokeAPIKey(db *sql.DB, tenantID int64, name string) (bool, error) {
+	result, err := db.Exec(
+		"UPDATE api_keys SET revoked_at = ? WHERE tenant_id = ? AND name = ? AND revoked_at IS NULL",
+		time.Now(), tenantID, name,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error revoking api key: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// ListAPIKeys 获取所有租户的 API Key，供管理命令使用
+func ListAPIKeys(db *sql.DB) ([]APIKey, error) {
+	return queryAPIKeys(db, "ORDER BY id")
+}
+
+func queryAPIKeys(db *sql.DB, where string, args ...interface{}) ([]APIKey, error) {
+	rows, err := db.Query(
+		"SELECT id, tenant_id, name, key_prefix, role, created_at, last_used_at, revoked_at FROM api_keys "+where,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying api keys: %v", err)
+	}
+	defer rows.Close()
+
+	keys := []APIKey{}
+	for rows.Next() {
+		var key APIKey
+		var lastUsedAt, revokedAt sql.NullTime
+		if err := rows.Scan(&key.ID, &key.TenantID, &key.Name, &key.Prefix, &key.Role, &key.CreatedAt, &lastUsedAt, &revokedAt); err != nil {
+			return nil, fmt.Errorf("error scanning api key: %v", err)
+		}
+		if lastUsedAt.Valid {
+			key.LastUsedAt = &lastUsedAt.Time
+		}
+		if revokedAt.Valid {
+			key.RevokedAt = &revokedAt.Time
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
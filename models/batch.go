@@ -0,0 +1,353 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 批量分析任务状态
+const (
+	BatchStatusPending   = "pending"
+	BatchStatusRunning   = "running"
+	BatchStatusCompleted = "completed"
+	BatchStatusCancelled = "cancelled"
+)
+
+// 批量分析任务中单条记录的状态
+const (
+	BatchItemPending = "pending"
+	BatchItemDone    = "done"
+	BatchItemFailed  = "failed"
+)
+
+// AnalysisBatch 批量分析任务
+type AnalysisBatch struct {
+	ID         int64      `json:"id"`
+	TenantID   int64      `json:"-"`
+	Status     string     `json:"status"`
+	Total      int        `json:"total"`
+	Done       int        `json:"done"`
+	Failed     int        `json:"failed"`
+	Remaining  int        `json:"remaining"`
+	CreatedBy  string     `json:"createdBy,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// AnalysisBatchItem 批量分析任务中的一条记录
+type AnalysisBatchItem struct {
+	BatchID   int64     `json:"batchId"`
+	RecordID  int64     `json:"recordId"`
+	Status    string    `json:"status"`
+	ResultID  int64     `json:"resultId,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AnalysisBatchReport 批量分析完成后的汇总
+type AnalysisBatchReport struct {
+	Succeeded         int                 `json:"succeeded"`
+	Failed            int                 `json:"failed"`
+	AverageConfidence float64             `json:"averageConfidence"`
+	SeverityCounts    map[int]int         `json:"severityCounts"`
+	Failures          []AnalysisBatchItem `json:"failures"`
+}
+
+// batchInsertSize 每条 INSERT 语句写入的任务记录数
+const batchInsertSize = 500
+
+// CreateAnalysisBatch 为租户创建批量分析任务及其待分析记录，createdBy 为发起任务的调用方
+func CreateAnalysisBatch(db *sql.DB, tenantID int64, recordIDs []int64, createdBy string) (*AnalysisBatch, error) {
+	seen := make(map[int64]bool, len(recordIDs))
+	unique := make([]int64, 0, len(recordIDs))
+	for _, id := range recordIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	recordIDs = unique
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	batch := &AnalysisBatch{
+		TenantID:  tenantID,
+		Status:    BatchStatusPending,
+		Total:     len(recordIDs),
+		Remaining: len(recordIDs),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	batch.ID, err = DialectOf(db).insertID(tx,
+		"INSERT INTO analysis_batches (tenant_id, status, total, created_by, created_at) VALUES (?, ?, ?, ?, ?)",
+		batch.TenantID, batch.Status, batch.Total, nullableString(batch.CreatedBy), batch.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating analysis batch: %v", err)
+	}
+
+	for start := 0; start < len(recordIDs); start += batchInsertSize {
+		end := start + batchInsertSize
+		if end > len(recordIDs) {
+			end = len(recordIDs)
+		}
+		placeholders := make([]string, 0, end-start)
+		args := make([]interface{}, 0, (end-start)*3)
+		for _, recordID := range recordIDs[start:end] {
+			placeholders = append(placeholders, "(?, ?, ?)")
+			args = append(args, batch.ID, recordID, batch.CreatedAt)
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO analysis_batch_items (batch_id, record_id, updated_at) VALUES "+strings.Join(placeholders, ", "),
+			args...,
+		); err != nil {
+			return nil, fmt.Errorf("error creating analysis batch items: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return batch, nil
+}
+
+// GetAnalysisBatch 获取租户的批量分析任务，不存在或属于其他租户时返回 nil
+func GetAnalysisBatch(db *sql.DB, tenantID int64, id int64) (*AnalysisBatch, error) {
+	batches, err := queryAnalysisBatches(db, "WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil || len(batches) == 0 {
+		return nil, err
+	}
+	return &batches[0], nil
+}
+
+// GetUnclaimedAnalysisBatches 获取所有租户尚未完成且无人执行的批量分析任务，执行者的心跳早于 staleBefore 的
+// 任务视为无人执行，用于服务重启或其他实例退出后继续执行
+func GetUnclaimedAnalysisBatches(db *sql.DB, staleBefore time.Time) ([]AnalysisBatch, error) {
+	return queryAnalysisBatches(db,
+		"WHERE status IN (?, ?) AND (owner IS NULL OR heartbeat_at IS NULL OR heartbeat_at < ?) ORDER BY id",
+		BatchStatusPending, BatchStatusRunning, staleBefore)
+}
+
+// ClaimAnalysisBatch 由 owner 认领租户尚未完成的任务。任务无人执行、已由 owner 执行或执行者的心跳早于
+// staleBefore 时认领成功并更新心跳，多个实例同时认领同一任务时只有一个成功
+func ClaimAnalysisBatch(db *sql.DB, tenantID int64, id int64, owner string, staleBefore time.Time) (bool, error) {
+	result, err := db.Exec(
+		`UPDATE analysis_batches SET owner = ?, heartbeat_at = ?
+		WHERE id = ? AND tenant_id = ? AND status IN (?, ?)
+		AND (owner IS NULL OR owner = ? OR heartbeat_at IS NULL OR heartbeat_at < ?)`,
+		owner, time.Now(), id, tenantID, BatchStatusPending, BatchStatusRunning, owner, staleBefore,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error claiming analysis batch: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// RenewAnalysisBatchLease 更新 owner 执行中任务的心跳，任务已结束或已被其他实例认领时返回 false
+func RenewAnalysisBatchLease(db *sql.DB, id int64, owner string) (bool, error) {
+	result, err := db.Exec(
+		"UPDATE analysis_batches SET heartbeat_at = ? WHERE id = ? AND owner = ? AND status IN (?, ?)",
+		time.Now(), id, owner, BatchStatusPending, BatchStatusRunning,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error renewing analysis batch lease: %v", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return affected > 0, err
+	}
+	// MySQL 不计入值未变化的行，心跳时间与上次相同时按任务当前状态判断
+	return AnalysisBatchOwnedBy(db, id, owner)
+}
+
+// AnalysisBatchOwnedBy 判断任务是否尚未结束且由 owner 执行
+func AnalysisBatchOwnedBy(db *sql.DB, id int64, owner string) (bool, error) {
+	var owned bool
+	err := db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM analysis_batches WHERE id = ? AND owner = ? AND status IN (?, ?))",
+		id, owner, BatchStatusPending, BatchStatusRunning,
+	).Scan(&owned)
+	if err != nil {
+		return false, fmt.Errorf("error checking analysis batch owner: %v", err)
+	}
+	return owned, nil
+}
+
+func queryAnalysisBatches(db *sql.DB, where string, args ...interface{}) ([]AnalysisBatch, error) {
+	rows, err := db.Query(
+		`SELECT id, tenant_id, status, total, done, failed, COALESCE(created_by, ''), created_at, started_at, finished_at
+		FROM analysis_batches `+where,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying analysis batches: %v", err)
+	}
+	defer rows.Close()
+
+	batches := []AnalysisBatch{}
+	for rows.Next() {
+		var batch AnalysisBatch
+		var startedAt, finishedAt sql.NullTime
+		if err := rows.Scan(&batch.ID, &batch.TenantID, &batch.Status, &batch.Total, &batch.Done, &batch.Failed, &batch.CreatedBy,
+			&batch.CreatedAt, &startedAt, &finishedAt); err != nil {
+			return nil, fmt.Errorf("error scanning analysis batch: %v", err)
+		}
+		if startedAt.Valid {
+			batch.StartedAt = &startedAt.Time
+		}
+		if finishedAt.Valid {
+			batch.FinishedAt = &finishedAt.Time
+		}
+		batch.Remaining = batch.Total - batch.Done - batch.Failed
+		batches = append(batches, batch)
+	}
+	return batches, rows.Err()
+}
+
+// StartAnalysisBatch 将租户的任务标记为执行中
+func StartAnalysisBatch(db *sql.DB, tenantID int64, id int64) error {
+	_, err := db.Exec(
+		`UPDATE analysis_batches SET status = ?, started_at = COALESCE(started_at, ?)
+		WHERE id = ? AND tenant_id = ? AND status IN (?, ?)`,
+		BatchStatusRunning, time.Now(), id, tenantID, BatchStatusPending, BatchStatusRunning,
+	)
+	if err != nil {
+		return fmt.Errorf("error starting analysis batch: %v", err)
+	}
+	return nil
+}
+
+// FinishAnalysisBatch 结束租户的任务，status 为 completed 或 cancelled；已结束的任务不受影响
+func FinishAnalysisBatch(db *sql.DB, tenantID int64, id int64, status string) (bool, error) {
+	result, err := db.Exec(
+		"UPDATE analysis_batches SET status = ?, finished_at = ? WHERE id = ? AND tenant_id = ? AND status IN (?, ?)",
+		status, time.Now(), id, tenantID, BatchStatusPending, BatchStatusRunning,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error finishing analysis batch: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// GetPendingBatchRecordIDs 获取任务中尚未分析的记录ID
+func GetPendingBatchRecordIDs(db *sql.DB, batchID int64) ([]int64, error) {
+	rows, err := db.Query(
+		"SELECT record_id FROM analysis_batch_items WHERE batch_id = ? AND status = ? ORDER BY record_id",
+		batchID, BatchItemPending,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying pending batch items: %v", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning batch item: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CompleteBatchItem 记录单条记录的分析结果并更新任务进度，resultID 为 0 表示分析失败
+func CompleteBatchItem(db *sql.DB, batchID int64, recordID int64, resultID int64, errMessage string) error {
+	status, counter := BatchItemDone, "done"
+	if resultID == 0 {
+		status, counter = BatchItemFailed, "failed"
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`UPDATE analysis_batch_items SET status = ?, result_id = ?, error = ?, updated_at = ?
+		WHERE batch_id = ? AND record_id = ? AND status = ?`,
+		status, nullableID(resultID), errMessage, time.Now(), batchID, recordID, BatchItemPending,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating batch item: %v", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return err
+	}
+
+	if _, err := tx.Exec(
+		fmt.Sprintf("UPDATE analysis_batches SET %s = %s + 1 WHERE id = ?", counter, counter), batchID,
+	); err != nil {
+		return fmt.Errorf("error updating batch progress: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
+// GetAnalysisBatchReport 汇总租户下任务的分析结果
+func GetAnalysisBatchReport(db *sql.DB, tenantID int64, batchID int64) (*AnalysisBatchReport, error) {
+	report := &AnalysisBatchReport{SeverityCounts: map[int]int{}, Failures: []AnalysisBatchItem{}}
+
+	rows, err := db.Query(
+		`SELECT a.severity, COUNT(*), AVG(a.confidence) FROM analysis_batch_items i
+		JOIN analysis_results a ON a.id = i.result_id
+		WHERE i.batch_id = ? AND i.batch_id IN (SELECT id FROM analysis_batches WHERE tenant_id = ?)
+		AND i.status = ? GROUP BY a.severity`,
+		batchID, tenantID, BatchItemDone,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing analysis batch: %v", err)
+	}
+	defer rows.Close()
+
+	var confidenceSum float64
+	for rows.Next() {
+		var severity, count int
+		var confidence float64
+		if err := rows.Scan(&severity, &count, &confidence); err != nil {
+			return nil, fmt.Errorf("error scanning batch summary: %v", err)
+		}
+		report.SeverityCounts[severity] = count
+		report.Succeeded += count
+		confidenceSum += confidence * float64(count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if report.Succeeded > 0 {
+		report.AverageConfidence = confidenceSum / float64(report.Succeeded)
+	}
+
+	failures, err := db.Query(
+		`SELECT batch_id, record_id, status, COALESCE(error, ''), updated_at FROM analysis_batch_items
+		WHERE batch_id = ? AND batch_id IN (SELECT id FROM analysis_batches WHERE tenant_id = ?)
+		AND status = ? ORDER BY record_id`,
+		batchID, tenantID, BatchItemFailed,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying batch failures: %v", err)
+	}
+	defer failures.Close()
+
+	for failures.Next() {
+		var item AnalysisBatchItem
+		if err := failures.Scan(&item.BatchID, &item.RecordID, &item.Status, &item.Error, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning batch failure: %v", err)
+		}
+		report.Failures = append(report.Failures, item)
+	}
+	report.Failed = len(report.Failures)
+	return report, failures.Err()
+}
@@ -1,24 +1,68 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
+// RecordTypes 支持的数据类型，与 api/openapi.json 中的 RecordType 保持一致
+var RecordTypes = []string{"text", "metrics", "log"}
+
+// MaxRecordContentSize data_records.content 为 TEXT 列，最多 65535 字节
+const MaxRecordContentSize = 65535
+
+// IsValidRecordType 判断是否为支持的数据类型
+func IsValidRecordType(recordType string) bool {
+	for _, t := range RecordTypes {
+		if t == recordType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRecord 校验记录的类型和内容
+func ValidateRecord(record *DataRecord) error {
+	if !IsValidRecordType(record.Type) {
+		return fmt.Errorf("type must be one of %s", strings.Join(RecordTypes, ", "))
+	}
+	if strings.TrimSpace(record.Content) == "" {
+		return fmt.Errorf("content must not be empty")
+	}
+	if len(record.Content) > MaxRecordContentSize {
+		return fmt.Errorf("content must not exceed %d bytes", MaxRecordContentSize)
+	}
+	return nil
+}
+
 // DataRecord 表示需要分析的数据记录
 type DataRecord struct {
-	ID        int64     `json:"id"`
-	Type      string    `json:"type"`      // 数据类型
-	Content   string    `json:"content"`   // 数据内容
-	Metadata  string    `json:"metadata"`  // 元数据
-	CreatedAt time.Time `json:"createdAt"` // 创建时间
-	UpdatedAt time.Time `json:"updatedAt"` // 更新时间
+	ID        int64           `json:"id"`
+	TenantID  int64           `json:"-"`                   // 所属租户
+	Type      string          `json:"type"`                // 数据类型
+	Content   string          `json:"content"`             // 数据内容
+	Metadata  json.RawMessage `json:"metadata"`            // 元数据，JSON 对象
+	Version   int             `json:"version"`             // 版本号，每次修改递增，用于乐观并发控制
+	CreatedAt time.Time       `json:"createdAt"`           // 创建时间
+	UpdatedAt time.Time       `json:"updatedAt"`           // 更新时间
+	DeletedAt *time.Time      `json:"deletedAt,omitempty"` // 软删除时间
+	CreatedBy string          `json:"createdBy,omitempty"` // 创建者
+	UpdatedBy string          `json:"updatedBy,omitempty"` // 最后修改者
 }
 
 // AnalysisResult 表示数据分析结果
 type AnalysisResult struct {
-	RecordID    int64     `json:"recordId"`    // 关联的数据记录ID
-	Analysis    string    `json:"analysis"`    // 分析结果
-	Suggestions []string  `json:"suggestions"` // 建议操作
-	Confidence  float64   `json:"confidence"`  // 置信度
-	CreatedAt   time.Time `json:"createdAt"`   // 创建时间
+	ID          int64     `json:"id"`                  // 分析结果ID
+	TenantID    int64     `json:"-"`                   // 所属租户
+	RecordID    int64     `json:"recordId"`            // 关联的数据记录ID
+	RevisionID  int64     `json:"revisionId"`          // 分析时的记录内容版本ID
+	Revision    int       `json:"revision,omitempty"`  // 分析时的记录内容版本号
+	Analysis    string    `json:"analysis"`            // 分析结果
+	Suggestions []string  `json:"suggestions"`         // 建议操作
+	Confidence  float64   `json:"confidence"`          // 置信度
+	Severity    int       `json:"severity"`            // 严重程度 1-5
+	CreatedBy   string    `json:"createdBy,omitempty"` // 发起分析的调用方
+	CreatedAt   time.Time `json:"createdAt"`           // 创建时间
 }
@@ -2,14 +2,30 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// ErrVersionConflict 记录已被修改或删除，请求中的版本号已过期
+var ErrVersionConflict = errors.New("record version conflict")
+
+// NewDB 连接数据库，根据 DSN 选择 MySQL、SQLite 或 PostgreSQL，见 DialectForDSN
 func NewDB(dsn string) (*sql.DB, error) {
-	db, err := sql.Open("mysql", dsn)
+	var db *sql.DB
+	var err error
+	switch DialectForDSN(dsn) {
+	case DialectSQLite:
+		db, err = openSQLite(dsn)
+	case DialectPostgres:
+		db, err = openPostgres(dsn)
+	default:
+		db, err = sql.Open("mysql", dsn)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to database: %v", err)
 	}
@@ -22,57 +38,275 @@ func NewDB(dsn string) (*sql.DB, error) {
 }
 
 func CreateDataRecord(db *sql.DB, record *DataRecord) error {
-	query := `INSERT INTO data_records (type, content, metadata, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)`
+	query := `INSERT INTO data_records (tenant_id, type, content, metadata, created_at, updated_at, created_by, updated_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	record.CreatedAt = now
 	record.UpdatedAt = now
+	record.Version = 1
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
 
-	result, err := db.Exec(query, record.Type, record.Content, record.Metadata,
-		record.CreatedAt, record.UpdatedAt)
+	record.UpdatedBy = record.CreatedBy
+	dialect := DialectOf(db)
+	id, err := dialect.insertID(tx, query, record.TenantID, record.Type, record.Content, metadataValue(record.Metadata),
+		record.CreatedAt, record.UpdatedAt, nullableString(record.CreatedBy), nullableString(record.UpdatedBy))
 	if err != nil {
 		return fmt.Errorf("error creating data record: %v", err)
 	}
+	record.ID = id
+
+	if _, err := createRecordRevision(tx, dialect, record, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
+// CreateDataRecords 在一个事务中批量插入记录及其第 1 个内容版本，并回填记录ID
+func CreateDataRecords(db *sql.DB, records []*DataRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
 
-	id, err := result.LastInsertId()
+	tx, err := db.Begin()
 	if err != nil {
-		return fmt.Errorf("error getting last insert id: %v", err)
+		return fmt.Errorf("error starting transaction: %v", err)
 	}
+	defer tx.Rollback()
 
-	record.ID = id
+	dialect := DialectOf(db)
+	consecutive, err := dialect.consecutiveInsertIDs(tx)
+	if err != nil {
+		return fmt.Errorf("error checking auto increment mode: %v", err)
+	}
+
+	now := time.Now()
+	for _, record := range records {
+		record.CreatedAt = now
+		record.UpdatedAt = now
+		record.Version = 1
+		record.UpdatedBy = record.CreatedBy
+	}
+	for start := 0; start < len(records); start += bulkInsertRows {
+		chunk := records[start:min(start+bulkInsertRows, len(records))]
+		if err := insertDataRecords(tx, dialect, chunk, consecutive, now); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
+// bulkInsertRows 批量插入时每条 INSERT 语句的最大行数，避免超出数据库的占位符数量限制
+const bulkInsertRows = 1000
+
+// insertDataRecords 以多行 INSERT 插入一批记录及其第 1 个内容版本并回填记录ID。
+// consecutive 为 false 时无法由一条语句推算各行的ID，改为逐行插入
+func insertDataRecords(tx *sql.Tx, dialect Dialect, records []*DataRecord, consecutive bool, now time.Time) error {
+	const insertRecord = "INSERT INTO data_records (tenant_id, type, content, metadata, created_at, updated_at, created_by, updated_by) VALUES "
+	if consecutive {
+		placeholders := make([]string, len(records))
+		args := make([]interface{}, 0, len(records)*8)
+		for i, record := range records {
+			placeholders[i] = "(?, ?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, record.TenantID, record.Type, record.Content, metadataValue(record.Metadata), now, now,
+				nullableString(record.CreatedBy), nullableString(record.UpdatedBy))
+		}
+		ids, err := dialect.insertIDs(tx, insertRecord+strings.Join(placeholders, ", "), len(records), args...)
+		if err != nil {
+			return fmt.Errorf("error creating data records: %v", err)
+		}
+		for i, record := range records {
+			record.ID = ids[i]
+		}
+	} else {
+		for _, record := range records {
+			id, err := dialect.insertID(tx, insertRecord+"(?, ?, ?, ?, ?, ?, ?, ?)",
+				record.TenantID, record.Type, record.Content, metadataValue(record.Metadata), now, now,
+				nullableString(record.CreatedBy), nullableString(record.UpdatedBy))
+			if err != nil {
+				return fmt.Errorf("error creating data records: %v", err)
+			}
+			record.ID = id
+		}
+	}
+
+	placeholders := make([]string, len(records))
+	args := make([]interface{}, 0, len(records)*5)
+	for i, record := range records {
+		placeholders[i] = "(?, 1, ?, ?, ?, ?)"
+		args = append(args, record.ID, record.Type, record.Content, metadataValue(record.Metadata), now)
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO record_revisions (record_id, revision, type, content, metadata, created_at) VALUES "+
+			strings.Join(placeholders, ", "),
+		args...,
+	); err != nil {
+		return fmt.Errorf("error creating record revisions: %v", err)
+	}
 	return nil
 }
 
-func GetDataRecord(db *sql.DB, id int64) (*DataRecord, error) {
-	query := `SELECT id, type, content, metadata, created_at, updated_at
-		FROM data_records WHERE id = ?`
+// GetDataRecord 获取租户的记录，不存在或属于其他租户时返回 nil
+func GetDataRecord(db *sql.DB, tenantID int64, id int64) (*DataRecord, error) {
+	query := `SELECT id, tenant_id, type, content, metadata, version, created_at, updated_at, deleted_at,
+		COALESCE(created_by, ''), COALESCE(updated_by, '') FROM data_records WHERE id = ? AND tenant_id = ?`
 
 	record := &DataRecord{}
-	err := db.QueryRow(query, id).Scan(
-		&record.ID, &record.Type, &record.Content, &record.Metadata,
-		&record.CreatedAt, &record.UpdatedAt)
+	var deletedAt sql.NullTime
+	err := db.QueryRow(query, id, tenantID).Scan(
+		&record.ID, &record.TenantID, &record.Type, &record.Content, (*[]byte)(&record.Metadata), &record.Version,
+		&record.CreatedAt, &record.UpdatedAt, &deletedAt, &record.CreatedBy, &record.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("error getting data record: %v", err)
 	}
+	if deletedAt.Valid {
+		record.DeletedAt = &deletedAt.Time
+	}
 
 	return record, nil
 }
 
+// UpdateDataRecord 更新记录的类型、内容和元数据，仅当当前版本号等于 record.Version 时生效，
+// 成功后 record.Version 递增。contentChanged 为 true 时同时保存一个新的内容版本
+func UpdateDataRecord(db *sql.DB, record *DataRecord, contentChanged bool) error {
+	query := `UPDATE data_records SET type = ?, content = ?, metadata = ?, version = version + 1, updated_at = ?,
+		updated_by = ? WHERE id = ? AND tenant_id = ? AND version = ? AND deleted_at IS NULL`
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.Exec(query, record.Type, record.Content, metadataValue(record.Metadata), now,
+		nullableString(record.UpdatedBy), record.ID, record.TenantID, record.Version)
+	if err != nil {
+		return fmt.Errorf("error updating data record: %v", err)
+	}
+	if err := checkVersionedUpdate(result); err != nil {
+		return err
+	}
+
+	if contentChanged {
+		if _, err := createRecordRevision(tx, DialectOf(db), record, now); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	record.Version++
+	record.UpdatedAt = now
+	return nil
+}
+
+// SoftDeleteDataRecord 软删除记录，仅当当前版本号等于 version 时生效，by 为执行删除的调用方
+func SoftDeleteDataRecord(db *sql.DB, tenantID int64, id int64, version int, by string) error {
+	query := `UPDATE data_records SET deleted_at = ?, version = version + 1, updated_at = ?, updated_by = ?
+		WHERE id = ? AND tenant_id = ? AND version = ? AND deleted_at IS NULL`
+
+	now := time.Now()
+	result, err := db.Exec(query, now, now, nullableString(by), id, tenantID, version)
+	if err != nil {
+		return fmt.Errorf("error deleting data record: %v", err)
+	}
+	return checkVersionedUpdate(result)
+}
+
+// RestoreDataRecord 恢复已软删除的记录，by 为执行恢复的调用方
+func RestoreDataRecord(db *sql.DB, tenantID int64, id int64, by string) error {
+	query := `UPDATE data_records SET deleted_at = NULL, version = version + 1, updated_at = ?, updated_by = ?
+		WHERE id = ? AND tenant_id = ? AND deleted_at IS NOT NULL`
+
+	if _, err := db.Exec(query, time.Now(), nullableString(by), id, tenantID); err != nil {
+		return fmt.Errorf("error restoring data record: %v", err)
+	}
+	return nil
+}
+
+// nullableString 将空字符串转换为 NULL
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// nullableID 将 0 转换为 NULL
+func nullableID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// checkTenantUpdate 判断按 id 和 tenant_id 更新 table 时是否匹配到了行。
+// MySQL 的影响行数不包含值没有变化的行，影响行数为 0 时再确认该行是否存在
+func checkTenantUpdate(db *sql.DB, result sql.Result, table string, tenantID int64, id int64) (bool, error) {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting affected rows: %v", err)
+	}
+	if affected > 0 {
+		return true, nil
+	}
+	var exists bool
+	if err := db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = ? AND tenant_id = ?)", id, tenantID,
+	).Scan(&exists); err != nil {
+		return false, fmt.Errorf("error checking %s: %v", table, err)
+	}
+	return exists, nil
+}
+
+// checkVersionedUpdate 条件更新未影响任何行时返回 ErrVersionConflict
+func checkVersionedUpdate(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %v", err)
+	}
+	if affected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
 func SaveAnalysisResult(db *sql.DB, result *AnalysisResult) error {
-	query := `INSERT INTO analysis_results (record_id, analysis, suggestions, confidence, created_at)
-		VALUES (?, ?, ?, ?, ?)`
+	query := `INSERT INTO analysis_results (tenant_id, record_id, revision_id, analysis, suggestions, confidence,
+		severity, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	result.CreatedAt = time.Now()
 
-	_, err := db.Exec(query, result.RecordID, result.Analysis,
-		fmt.Sprintf("%v", result.Suggestions), result.Confidence, result.CreatedAt)
+	suggestions, err := json.Marshal(result.Suggestions)
+	if err != nil {
+		return fmt.Errorf("error encoding suggestions: %v", err)
+	}
+
+	id, err := DialectOf(db).insertID(db, query, result.TenantID, result.RecordID, nullableID(result.RevisionID), result.Analysis,
+		string(suggestions), result.Confidence, result.Severity, nullableString(result.CreatedBy), result.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("error saving analysis result: %v", err)
 	}
+	result.ID = id
 
 	return nil
 }
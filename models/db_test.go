@@ -0,0 +1,648 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// openTestDB 连接 dsn 指定的数据库并执行 migrations/<数据库类型> 下的迁移
+func openTestDB(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+	db, err := NewDB(dsn)
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	dialect := DialectOf(db)
+	var driver database.Driver
+	switch dialect {
+	case DialectSQLite:
+		driver, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	case DialectPostgres:
+		driver, err = postgres.WithInstance(db, &postgres.Config{})
+	default:
+		t.Fatalf("unsupported test database: %s", dialect)
+	}
+	if err != nil {
+		t.Fatalf("creating %s migration driver: %v", dialect, err)
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://../migrations/"+string(dialect), string(dialect), driver)
+	if err != nil {
+		t.Fatalf("creating migrate instance: %v", err)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		t.Fatalf("running migrations: %v", err)
+	}
+	return db
+}
+
+// modelTestCases 与数据库类型无关的 models 测试，每个用例使用单独创建的租户，互不影响
+var modelTestCases = []struct {
+	name string
+	run  func(t *testing.T, db *sql.DB, tenantID int64)
+}{
+	{"RecordCRUD", testRecordCRUD},
+	{"CreateDataRecords", testCreateDataRecords},
+	{"MetadataFilters", testMetadataFilters},
+	{"UpdateStatus", testUpdateStatus},
+	{"MergeTags", testMergeTags},
+	{"TagUsage", testTagUsage},
+	{"TenantUsage", testTenantUsage},
+	{"RateLimit", testRateLimit},
+	{"Idempotency", testIdempotency},
+	{"NotificationSettings", testNotificationSettings},
+	{"AnalysisBatchLease", testAnalysisBatchLease},
+	{"APIKeyNamesPerTenant", testAPIKeyNamesPerTenant},
+}
+
+// runModelTests 在 db 上执行全部 modelTestCases
+func runModelTests(t *testing.T, db *sql.DB) {
+	for _, tc := range modelTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, db, createTestTenant(t, db))
+		})
+	}
+}
+
+func createTestTenant(t *testing.T, db *sql.DB) int64 {
+	t.Helper()
+	tenant := &Tenant{Slug: fmt.Sprintf("test-%d", time.Now().UnixNano()), Name: t.Name()}
+	if err := CreateTenant(db, tenant); err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+	return tenant.ID
+}
+
+func createTestRecord(t *testing.T, db *sql.DB, tenantID int64, content string, metadata string) *DataRecord {
+	t.Helper()
+	record := &DataRecord{TenantID: tenantID, Type: "log", Content: content, CreatedBy: "tester"}
+	if metadata != "" {
+		record.Metadata = json.RawMessage(metadata)
+	}
+	if err := CreateDataRecord(db, record); err != nil {
+		t.Fatalf("CreateDataRecord: %v", err)
+	}
+	return record
+}
+
+func mustGetRecord(t *testing.T, db *sql.DB, tenantID int64, id int64) *DataRecord {
+	t.Helper()
+	record, err := GetDataRecord(db, tenantID, id)
+	if err != nil {
+		t.Fatalf("GetDataRecord: %v", err)
+	}
+	if record == nil {
+		t.Fatalf("record %d not found", id)
+	}
+	return record
+}
+
+func metadataMap(t *testing.T, record *DataRecord) map[string]interface{} {
+	t.Helper()
+	metadata := map[string]interface{}{}
+	if err := json.Unmarshal(record.Metadata, &metadata); err != nil {
+		t.Fatalf("decoding metadata %s: %v", record.Metadata, err)
+	}
+	return metadata
+}
+
+func listRecordIDs(t *testing.T, db *sql.DB, filter RecordFilter) []int64 {
+	t.Helper()
+	filter.SortBy = "id"
+	filter.Order = "asc"
+	page, err := ListDataRecords(db, filter)
+	if err != nil {
+		t.Fatalf("ListDataRecords: %v", err)
+	}
+	ids := []int64{}
+	for _, record := range page.Records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
+
+func recordTagNames(t *testing.T, db *sql.DB, tenantID int64, recordID int64) []string {
+	t.Helper()
+	tags, err := GetTagsByRecordID(db, tenantID, recordID)
+	if err != nil {
+		t.Fatalf("GetTagsByRecordID: %v", err)
+	}
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.TagName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func equalIDs(a, b []int64) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func testRecordCRUD(t *testing.T, db *sql.DB, tenantID int64) {
+	record := createTestRecord(t, db, tenantID, "disk full", `{"host":"web-1"}`)
+	if record.ID == 0 || record.Version != 1 {
+		t.Fatalf("created record = %+v", record)
+	}
+
+	got := mustGetRecord(t, db, tenantID, record.ID)
+	if got.Content != "disk full" || got.CreatedBy != "tester" || metadataMap(t, got)["host"] != "web-1" {
+		t.Errorf("GetDataRecord = %+v", got)
+	}
+	if other, err := GetDataRecord(db, tenantID+1, record.ID); err != nil || other != nil {
+		t.Errorf("GetDataRecord from another tenant = %v, %v; want nil", other, err)
+	}
+
+	got.Content = "disk almost full"
+	got.UpdatedBy = "editor"
+	if err := UpdateDataRecord(db, got, true); err != nil {
+		t.Fatalf("UpdateDataRecord: %v", err)
+	}
+	if got.Version != 2 {
+		t.Errorf("version after update = %d, want 2", got.Version)
+	}
+	stale := *got
+	stale.Version = 1
+	if err := UpdateDataRecord(db, &stale, false); err != ErrVersionConflict {
+		t.Errorf("update with stale version: err = %v, want ErrVersionConflict", err)
+	}
+
+	revisions, err := ListRecordRevisions(db, tenantID, record.ID)
+	if err != nil {
+		t.Fatalf("ListRecordRevisions: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].Content != "disk full" || revisions[1].Content != "disk almost full" {
+		t.Errorf("revisions = %+v", revisions)
+	}
+	if others, err := ListRecordRevisions(db, tenantID+1, record.ID); err != nil || len(others) != 0 {
+		t.Errorf("ListRecordRevisions from another tenant = %v, %v; want none", others, err)
+	}
+
+	if err := SoftDeleteDataRecord(db, tenantID, record.ID, got.Version, "editor"); err != nil {
+		t.Fatalf("SoftDeleteDataRecord: %v", err)
+	}
+	if deleted := mustGetRecord(t, db, tenantID, record.ID); deleted.DeletedAt == nil || deleted.Version != 3 {
+		t.Errorf("deleted record = %+v", deleted)
+	}
+	if ids := listRecordIDs(t, db, RecordFilter{TenantID: tenantID}); len(ids) != 0 {
+		t.Errorf("ListDataRecords returned deleted records %v", ids)
+	}
+
+	if err := RestoreDataRecord(db, tenantID, record.ID, "editor"); err != nil {
+		t.Fatalf("RestoreDataRecord: %v", err)
+	}
+	if restored := mustGetRecord(t, db, tenantID, record.ID); restored.DeletedAt != nil {
+		t.Errorf("restored record still deleted: %+v", restored)
+	}
+}
+
+func testCreateDataRecords(t *testing.T, db *sql.DB, tenantID int64) {
+	records := []*DataRecord{
+		{TenantID: tenantID, Type: "log", Content: "first"},
+		{TenantID: tenantID, Type: "metrics", Content: "second", Metadata: json.RawMessage(`{"cpu":90}`)},
+		{TenantID: tenantID, Type: "text", Content: "third", CreatedBy: "importer"},
+	}
+	if err := CreateDataRecords(db, records); err != nil {
+		t.Fatalf("CreateDataRecords: %v", err)
+	}
+
+	seen := map[int64]bool{}
+	for _, record := range records {
+		if record.ID == 0 || seen[record.ID] {
+			t.Fatalf("record IDs not assigned uniquely: %d", record.ID)
+		}
+		seen[record.ID] = true
+
+		got := mustGetRecord(t, db, tenantID, record.ID)
+		if got.Content != record.Content || got.Type != record.Type || got.Version != 1 {
+			t.Errorf("record %d = %+v, want %+v", record.ID, got, record)
+		}
+		revision, err := GetLatestRecordRevision(db, tenantID, record.ID)
+		if err != nil {
+			t.Fatalf("GetLatestRecordRevision: %v", err)
+		}
+		if revision == nil || revision.RecordID != record.ID || revision.Revision != 1 ||
+			revision.Content != record.Content {
+			t.Errorf("revision of record %d = %+v", record.ID, revision)
+		}
+	}
+
+	// 超过单条 INSERT 行数上限时分多条语句插入，ID 仍需与各自的记录对应
+	many := make([]*DataRecord, bulkInsertRows+2)
+	for i := range many {
+		many[i] = &DataRecord{TenantID: tenantID, Type: "bulk", Content: fmt.Sprintf("bulk-%d", i)}
+	}
+	if err := CreateDataRecords(db, many); err != nil {
+		t.Fatalf("CreateDataRecords: %v", err)
+	}
+	for _, i := range []int{0, bulkInsertRows - 1, bulkInsertRows, bulkInsertRows + 1} {
+		got := mustGetRecord(t, db, tenantID, many[i].ID)
+		if got.Content != many[i].Content {
+			t.Errorf("record %d content = %q, want %q", many[i].ID, got.Content, many[i].Content)
+		}
+		revision, err := GetLatestRecordRevision(db, tenantID, many[i].ID)
+		if err != nil {
+			t.Fatalf("GetLatestRecordRevision: %v", err)
+		}
+		if revision == nil || revision.Content != many[i].Content {
+			t.Errorf("revision of record %d = %+v", many[i].ID, revision)
+		}
+	}
+}
+
+func testMetadataFilters(t *testing.T, db *sql.DB, tenantID int64) {
+	web := createTestRecord(t, db, tenantID, "web",
+		`{"status":"open","source":{"host":"web-1"},"labels":["prod","edge"]}`)
+	db1 := createTestRecord(t, db, tenantID, "db",
+		`{"status":"closed","source":{"host":"db-1"},"labels":["prod"]}`)
+	plain := createTestRecord(t, db, tenantID, "plain", "")
+
+	for _, tc := range []struct {
+		name   string
+		filter RecordFilter
+		want   []int64
+	}{
+		{"nested path", RecordFilter{Metadata: map[string]string{"source.host": "web-1"}}, []int64{web.ID}},
+		{"array contains", RecordFilter{Metadata: map[string]string{"labels": "prod"}}, []int64{web.ID, db1.ID}},
+		{"combined", RecordFilter{Metadata: map[string]string{"labels": "prod", "source.host": "db-1"}}, []int64{db1.ID}},
+		{"no match", RecordFilter{Metadata: map[string]string{"source.host": "cache-1"}}, []int64{}},
+		{"status", RecordFilter{Status: "open"}, []int64{web.ID}},
+		{"none", RecordFilter{}, []int64{web.ID, db1.ID, plain.ID}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.TenantID = tenantID
+			if got := listRecordIDs(t, db, tc.filter); !equalIDs(got, tc.want) {
+				t.Errorf("ids = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func testUpdateStatus(t *testing.T, db *sql.DB, tenantID int64) {
+	record := createTestRecord(t, db, tenantID, "alert", `{"status":"open","host":"web-1"}`)
+
+	if err := UpdateStatus(db, tenantID, fmt.Sprint(record.ID), "resolved"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	got := mustGetRecord(t, db, tenantID, record.ID)
+	metadata := metadataMap(t, got)
+	if metadata["status"] != "resolved" || metadata["host"] != "web-1" {
+		t.Errorf("metadata after UpdateStatus = %v", metadata)
+	}
+	if got.Version != 2 {
+		t.Errorf("version = %d, want 2", got.Version)
+	}
+	if ids := listRecordIDs(t, db, RecordFilter{TenantID: tenantID, Status: "resolved"}); !equalIDs(ids, []int64{record.ID}) {
+		t.Errorf("records with status resolved = %v", ids)
+	}
+
+	// 其他租户不能修改该记录
+	if err := UpdateStatus(db, tenantID+1, fmt.Sprint(record.ID), "hijacked"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if status := metadataMap(t, mustGetRecord(t, db, tenantID, record.ID))["status"]; status != "resolved" {
+		t.Errorf("status changed by another tenant to %v", status)
+	}
+}
+
+func testMergeTags(t *testing.T, db *sql.DB, tenantID int64) {
+	both := createTestRecord(t, db, tenantID, "both", "")
+	fromOnly := createTestRecord(t, db, tenantID, "from only", "")
+	for _, tag := range []struct {
+		record *DataRecord
+		name   string
+	}{{both, "cpu"}, {both, "processor"}, {fromOnly, "processor"}} {
+		if _, err := AddTag(db, tenantID, tag.record.ID, tag.name, TagSourceHuman); err != nil {
+			t.Fatalf("AddTag: %v", err)
+		}
+	}
+	if err := CreateTagAlias(db, tenantID, &TagAlias{Alias: "proc", TagName: "processor"}); err != nil {
+		t.Fatalf("CreateTagAlias: %v", err)
+	}
+
+	merged, err := MergeTags(db, tenantID, "processor", "cpu")
+	if err != nil {
+		t.Fatalf("MergeTags: %v", err)
+	}
+	if merged != 1 {
+		t.Errorf("merged = %d, want 1", merged)
+	}
+	for _, record := range []*DataRecord{both, fromOnly} {
+		if names := recordTagNames(t, db, tenantID, record.ID); fmt.Sprint(names) != "[cpu]" {
+			t.Errorf("tags of record %d = %v, want [cpu]", record.ID, names)
+		}
+	}
+
+	aliases, err := ListTagAliases(db, tenantID)
+	if err != nil {
+		t.Fatalf("ListTagAliases: %v", err)
+	}
+	got := map[string]string{}
+	for _, alias := range aliases {
+		got[alias.Alias] = alias.TagName
+	}
+	if len(got) != 2 || got["processor"] != "cpu" || got["proc"] != "cpu" {
+		t.Errorf("aliases = %v, want processor and proc pointing to cpu", got)
+	}
+
+	// 合并后添加旧名称或别名都会得到规范名称
+	third := createTestRecord(t, db, tenantID, "third", "")
+	for _, name := range []string{"processor", "proc"} {
+		if added, err := AddTag(db, tenantID, third.ID, name, TagSourceLLM); err != nil || added != "cpu" {
+			t.Errorf("AddTag(%s) = %q, %v; want cpu", name, added, err)
+		}
+	}
+	if names := recordTagNames(t, db, tenantID, third.ID); fmt.Sprint(names) != "[cpu]" {
+		t.Errorf("tags of record %d = %v, want [cpu]", third.ID, names)
+	}
+
+	if _, err := MergeTags(db, tenantID, "cpu", "cpu"); err == nil {
+		t.Errorf("merging a tag into itself succeeded")
+	}
+}
+
+func testTagUsage(t *testing.T, db *sql.DB, tenantID int64) {
+	record := createTestRecord(t, db, tenantID, "usage", "")
+	if _, err := AddTag(db, tenantID, record.ID, "cpu", TagSourceHuman); err != nil {
+		t.Fatalf("AddTag: %v", err)
+	}
+	if _, err := AddTag(db, tenantID, record.ID, "cpu", TagSourceLLM); err != nil {
+		t.Fatalf("AddTag again: %v", err)
+	}
+	if _, err := AddTag(db, tenantID+1, record.ID, "foreign", TagSourceHuman); err != nil {
+		t.Fatalf("AddTag from another tenant: %v", err)
+	}
+	if err := AddVocabularyTag(db, tenantID, &VocabularyTag{Name: "memory", Description: "内存"}); err != nil {
+		t.Fatalf("AddVocabularyTag: %v", err)
+	}
+	if err := AddVocabularyTag(db, tenantID, &VocabularyTag{Name: "memory", Description: "内存使用率"}); err != nil {
+		t.Fatalf("AddVocabularyTag again: %v", err)
+	}
+
+	usages, err := ListTagUsage(db, tenantID)
+	if err != nil {
+		t.Fatalf("ListTagUsage: %v", err)
+	}
+	want := []TagUsage{
+		{Name: "cpu", Count: 1, HumanCount: 1},
+		{Name: "memory", InVocabulary: true},
+	}
+	if fmt.Sprint(usages) != fmt.Sprint(want) {
+		t.Errorf("usages = %+v, want %+v", usages, want)
+	}
+
+	vocabulary, err := ListVocabularyTags(db, tenantID)
+	if err != nil {
+		t.Fatalf("ListVocabularyTags: %v", err)
+	}
+	if len(vocabulary) != 1 || vocabulary[0].Description != "内存使用率" {
+		t.Errorf("vocabulary = %+v", vocabulary)
+	}
+}
+
+func testTenantUsage(t *testing.T, db *sql.DB, tenantID int64) {
+	for _, tokens := range []int64{100, 250} {
+		if err := AddTenantUsage(db, tenantID, tokens); err != nil {
+			t.Fatalf("AddTenantUsage: %v", err)
+		}
+	}
+	usage, err := GetTenantUsage(db, tenantID)
+	if err != nil {
+		t.Fatalf("GetTenantUsage: %v", err)
+	}
+	if usage.Requests != 2 || usage.Tokens != 350 {
+		t.Errorf("usage = %+v, want 2 requests and 350 tokens", usage)
+	}
+
+	tenant := &Tenant{ID: tenantID, MonthlyTokenBudget: 400}
+	if err := CheckTenantBudget(db, tenant); err != nil {
+		t.Errorf("CheckTenantBudget under budget: %v", err)
+	}
+	tenant.MonthlyTokenBudget = 350
+	if err := CheckTenantBudget(db, tenant); !errors.Is(err, ErrTenantBudgetExceeded) {
+		t.Errorf("CheckTenantBudget at budget: err = %v, want ErrTenantBudgetExceeded", err)
+	}
+}
+
+func testRateLimit(t *testing.T, db *sql.DB, tenantID int64) {
+	key := fmt.Sprintf("test:%d", tenantID)
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if allowed, _, err := TakeRateLimitToken(db, key, 2, 1, now); err != nil || !allowed {
+			t.Fatalf("take %d: allowed = %v, err = %v", i+1, allowed, err)
+		}
+	}
+	allowed, retryAfter, err := TakeRateLimitToken(db, key, 2, 1, now)
+	if err != nil {
+		t.Fatalf("TakeRateLimitToken: %v", err)
+	}
+	if allowed || retryAfter <= 0 || retryAfter > time.Second {
+		t.Errorf("exhausted bucket: allowed = %v, retryAfter = %v", allowed, retryAfter)
+	}
+	if allowed, _, err := TakeRateLimitToken(db, key, 2, 1, now.Add(time.Second)); err != nil || !allowed {
+		t.Errorf("after refill: allowed = %v, err = %v", allowed, err)
+	}
+}
+
+func testIdempotency(t *testing.T, db *sql.DB, tenantID int64) {
+	scope := fmt.Sprintf("test:%d", tenantID)
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	staleBefore := now.Add(-time.Minute)
+
+	if existing, err := ReserveIdempotencyKey(db, scope, "k1", "hash", expiresAt, staleBefore); err != nil || existing != nil {
+		t.Fatalf("first reservation = %+v, %v; want nil", existing, err)
+	}
+	existing, err := ReserveIdempotencyKey(db, scope, "k1", "hash", expiresAt, staleBefore)
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if existing == nil || existing.StatusCode != 0 || existing.RequestHash != "hash" {
+		t.Fatalf("reservation in progress = %+v", existing)
+	}
+
+	if err := CompleteIdempotencyKey(db, scope, "k1", 201, "application/json", []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("CompleteIdempotencyKey: %v", err)
+	}
+	existing, err = ReserveIdempotencyKey(db, scope, "k1", "hash", expiresAt, staleBefore)
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if existing == nil || existing.StatusCode != 201 || string(existing.Body) != `{"id":1}` {
+		t.Fatalf("completed reservation = %+v", existing)
+	}
+
+	if err := ReleaseIdempotencyKey(db, scope, "k1"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	if existing, err := ReserveIdempotencyKey(db, scope, "k1", "other", expiresAt, staleBefore); err != nil || existing != nil {
+		t.Errorf("reservation after release = %+v, %v; want nil", existing, err)
+	}
+}
+
+func testNotificationSettings(t *testing.T, db *sql.DB, tenantID int64) {
+	group := &NotificationGroup{TenantID: tenantID, Name: "oncall", Channel: "email", Recipients: []string{"ops@example.com"}}
+	if err := CreateNotificationGroup(db, group); err != nil {
+		t.Fatalf("CreateNotificationGroup: %v", err)
+	}
+	route := &NotificationRoute{TenantID: tenantID, GroupID: group.ID, EndHour: 24, Enabled: true}
+	if err := CreateNotificationRoute(db, route); err != nil {
+		t.Fatalf("CreateNotificationRoute: %v", err)
+	}
+	policy := &EscalationPolicy{TenantID: tenantID, Name: "l1", TargetGroupID: group.ID, Level: 1,
+		AckTimeoutMinutes: 30, Enabled: true}
+	if err := CreateEscalationPolicy(db, policy); err != nil {
+		t.Fatalf("CreateEscalationPolicy: %v", err)
+	}
+
+	// 未修改任何字段的更新同样视为匹配到了行
+	for name, update := range map[string]func() (bool, error){
+		"group":  func() (bool, error) { return UpdateNotificationGroup(db, group) },
+		"route":  func() (bool, error) { return UpdateNotificationRoute(db, route) },
+		"policy": func() (bool, error) { return UpdateEscalationPolicy(db, policy) },
+	} {
+		if found, err := update(); err != nil || !found {
+			t.Errorf("unchanged %s update = %v, %v; want true", name, found, err)
+		}
+	}
+
+	// 其他租户的更新和删除都匹配不到行
+	otherGroup, otherRoute, otherPolicy := *group, *route, *policy
+	otherGroup.TenantID, otherRoute.TenantID, otherPolicy.TenantID = tenantID+1, tenantID+1, tenantID+1
+	for name, change := range map[string]func() (bool, error){
+		"update group":  func() (bool, error) { return UpdateNotificationGroup(db, &otherGroup) },
+		"update route":  func() (bool, error) { return UpdateNotificationRoute(db, &otherRoute) },
+		"update policy": func() (bool, error) { return UpdateEscalationPolicy(db, &otherPolicy) },
+		"delete group":  func() (bool, error) { return DeleteNotificationGroup(db, tenantID+1, group.ID) },
+		"delete route":  func() (bool, error) { return DeleteNotificationRoute(db, tenantID+1, route.ID) },
+		"delete policy": func() (bool, error) { return DeleteEscalationPolicy(db, tenantID+1, policy.ID) },
+	} {
+		if found, err := change(); err != nil || found {
+			t.Errorf("%s from another tenant = %v, %v; want false", name, found, err)
+		}
+	}
+
+	for name, remove := range map[string]func() (bool, error){
+		"policy": func() (bool, error) { return DeleteEscalationPolicy(db, tenantID, policy.ID) },
+		"route":  func() (bool, error) { return DeleteNotificationRoute(db, tenantID, route.ID) },
+	} {
+		if found, err := remove(); err != nil || !found {
+			t.Errorf("delete %s = %v, %v; want true", name, found, err)
+		}
+		if found, err := remove(); err != nil || found {
+			t.Errorf("delete missing %s = %v, %v; want false", name, found, err)
+		}
+	}
+	if found, err := UpdateEscalationPolicy(db, policy); err != nil || found {
+		t.Errorf("update deleted policy = %v, %v; want false", found, err)
+	}
+}
+
+func testAnalysisBatchLease(t *testing.T, db *sql.DB, tenantID int64) {
+	record := createTestRecord(t, db, tenantID, "batch item", "")
+	batch, err := CreateAnalysisBatch(db, tenantID, []int64{record.ID}, "alice")
+	if err != nil {
+		t.Fatalf("CreateAnalysisBatch: %v", err)
+	}
+	unclaimed := func(staleBefore time.Time) bool {
+		t.Helper()
+		batches, err := GetUnclaimedAnalysisBatches(db, staleBefore)
+		if err != nil {
+			t.Fatalf("GetUnclaimedAnalysisBatches: %v", err)
+		}
+		for _, b := range batches {
+			if b.ID == batch.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	now := time.Now()
+	if !unclaimed(now.Add(-time.Minute)) {
+		t.Errorf("new batch not listed as unclaimed")
+	}
+	if claimed, err := ClaimAnalysisBatch(db, tenantID+1, batch.ID, "a", now.Add(-time.Minute)); err != nil || claimed {
+		t.Errorf("claim from other tenant = %v, %v; want false", claimed, err)
+	}
+	if claimed, err := ClaimAnalysisBatch(db, tenantID, batch.ID, "a", now.Add(-time.Minute)); err != nil || !claimed {
+		t.Fatalf("first claim = %v, %v; want true", claimed, err)
+	}
+	if claimed, err := ClaimAnalysisBatch(db, tenantID, batch.ID, "b", now.Add(-time.Minute)); err != nil || claimed {
+		t.Errorf("claim of a live batch = %v, %v; want false", claimed, err)
+	}
+	if unclaimed(now.Add(-time.Minute)) {
+		t.Errorf("claimed batch listed as unclaimed")
+	}
+	if owned, err := RenewAnalysisBatchLease(db, batch.ID, "a"); err != nil || !owned {
+		t.Errorf("renew by owner = %v, %v; want true", owned, err)
+	}
+	if owned, err := RenewAnalysisBatchLease(db, batch.ID, "b"); err != nil || owned {
+		t.Errorf("renew by other instance = %v, %v; want false", owned, err)
+	}
+
+	// 心跳超时后其他实例可以接管，原执行者随之失去任务
+	stale := time.Now().Add(time.Minute)
+	if !unclaimed(stale) {
+		t.Errorf("stale batch not listed as unclaimed")
+	}
+	if claimed, err := ClaimAnalysisBatch(db, tenantID, batch.ID, "b", stale); err != nil || !claimed {
+		t.Fatalf("claim of a stale batch = %v, %v; want true", claimed, err)
+	}
+	if owned, err := AnalysisBatchOwnedBy(db, batch.ID, "a"); err != nil || owned {
+		t.Errorf("previous owner still owns the batch: %v, %v", owned, err)
+	}
+
+	if _, err := FinishAnalysisBatch(db, tenantID, batch.ID, BatchStatusCancelled); err != nil {
+		t.Fatalf("FinishAnalysisBatch: %v", err)
+	}
+	if owned, err := AnalysisBatchOwnedBy(db, batch.ID, "b"); err != nil || owned {
+		t.Errorf("cancelled batch still owned: %v, %v", owned, err)
+	}
+	if owned, err := RenewAnalysisBatchLease(db, batch.ID, "b"); err != nil || owned {
+		t.Errorf("renew of a cancelled batch = %v, %v; want false", owned, err)
+	}
+	if claimed, err := ClaimAnalysisBatch(db, tenantID, batch.ID, "c", stale); err != nil || claimed {
+		t.Errorf("claim of a cancelled batch = %v, %v; want false", claimed, err)
+	}
+}
+
+func testAPIKeyNamesPerTenant(t *testing.T, db *sql.DB, tenantID int64) {
+	otherTenantID := createTestTenant(t, db)
+	if _, _, err := CreateAPIKey(db, tenantID, "bot", RoleReader); err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	_, otherKey, err := CreateAPIKey(db, otherTenantID, "bot", RoleReader)
+	if err != nil {
+		t.Fatalf("same name in another tenant: %v", err)
+	}
+	if _, _, err := CreateAPIKey(db, tenantID, "bot", RoleReader); err == nil {
+		t.Errorf("duplicate name in the same tenant was accepted")
+	}
+
+	if revoked, err := RevokeAPIKey(db, tenantID, "bot"); err != nil || !revoked {
+		t.Fatalf("RevokeAPIKey = %v, %v; want true", revoked, err)
+	}
+	if revoked, err := RevokeAPIKey(db, tenantID, "bot"); err != nil || revoked {
+		t.Errorf("second revoke = %v, %v; want false", revoked, err)
+	}
+	apiKey, err := AuthenticateAPIKey(db, otherKey)
+	if err != nil {
+		t.Fatalf("AuthenticateAPIKey: %v", err)
+	}
+	if apiKey == nil || apiKey.TenantID != otherTenantID {
+		t.Errorf("other tenant's key = %+v, want it still active", apiKey)
+	}
+}
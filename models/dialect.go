@@ -0,0 +1,226 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Dialect 数据库类型，名称与 golang-migrate 的数据库驱动名和 migrations 下的目录名一致
+type Dialect string
+
+const (
+	DialectMySQL    Dialect = "mysql"
+	DialectSQLite   Dialect = "sqlite3"
+	DialectPostgres Dialect = "postgres"
+)
+
+// sqliteDSNPrefix SQLite 的 DSN 前缀，如 sqlite://data/deepseek.db
+const sqliteDSNPrefix = "sqlite://"
+
+// DialectForDSN 根据 DSN 选择数据库：sqlite:// 或 file: 开头时使用 SQLite 文件数据库，
+// postgres:// 或 postgresql:// 开头时使用 PostgreSQL，其他为 MySQL
+func DialectForDSN(dsn string) Dialect {
+	switch {
+	case strings.HasPrefix(dsn, sqliteDSNPrefix) || strings.HasPrefix(dsn, "file:"):
+		return DialectSQLite
+	case strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://"):
+		return DialectPostgres
+	}
+	return DialectMySQL
+}
+
+// DialectOf 返回 db 连接的数据库类型
+func DialectOf(db *sql.DB) Dialect {
+	switch db.Driver().(type) {
+	case *sqlite3.SQLiteDriver:
+		return DialectSQLite
+	case *pq.Driver:
+		return DialectPostgres
+	case *mysql.MySQLDriver:
+		return DialectMySQL
+	}
+	return DialectMySQL
+}
+
+// insertIgnore 插入时忽略主键和唯一键冲突的 INSERT 语句开头，语句末尾需要接 ignoreConflicts
+func (d Dialect) insertIgnore() string {
+	switch d {
+	case DialectSQLite:
+		return "INSERT OR IGNORE INTO"
+	case DialectPostgres:
+		return "INSERT INTO"
+	}
+	return "INSERT IGNORE INTO"
+}
+
+// ignoreConflicts 与 insertIgnore 配合使用的 INSERT 语句结尾
+func (d Dialect) ignoreConflicts() string {
+	if d == DialectPostgres {
+		return " ON CONFLICT DO NOTHING"
+	}
+	return ""
+}
+
+// updateIgnore 更新时跳过会导致主键或唯一键冲突的行的 UPDATE 语句开头。
+// PostgreSQL 没有对应的语法，需要在条件中排除会冲突的行
+func (d Dialect) updateIgnore() string {
+	switch d {
+	case DialectSQLite:
+		return "UPDATE OR IGNORE"
+	case DialectPostgres:
+		return "UPDATE"
+	}
+	return "UPDATE IGNORE"
+}
+
+// onConflictUpdate 主键或唯一键 keys 冲突时改为更新的子句，后面接 SET 的赋值列表
+func (d Dialect) onConflictUpdate(keys ...string) string {
+	if d == DialectSQLite || d == DialectPostgres {
+		return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET", strings.Join(keys, ", "))
+	}
+	return "ON DUPLICATE KEY UPDATE"
+}
+
+// excluded 在 onConflictUpdate 的赋值中引用本次插入的列值
+func (d Dialect) excluded(column string) string {
+	if d == DialectSQLite || d == DialectPostgres {
+		return "excluded." + column
+	}
+	return "VALUES(" + column + ")"
+}
+
+// jsonPath 将 MetadataJSONPath 返回的 JSON 路径转换为 jsonText 等方法使用的路径参数，
+// PostgreSQL 使用文本数组形式的路径，如 $.source.host 转换为 {source,host}
+func (d Dialect) jsonPath(path string) string {
+	if d == DialectPostgres {
+		return "{" + strings.ReplaceAll(strings.TrimPrefix(path, "$."), ".", ",") + "}"
+	}
+	return path
+}
+
+// jsonText 取 JSON 列 column 中路径 path 的值，字符串去掉引号，其他标量转换为文本
+func (d Dialect) jsonText(column, path string) string {
+	switch d {
+	case DialectSQLite:
+		return fmt.Sprintf("CAST(json_extract(%s, %s) AS TEXT)", column, path)
+	case DialectPostgres:
+		return fmt.Sprintf("(%s #>> CAST(%s AS TEXT[]))", column, path)
+	}
+	return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", column, path)
+}
+
+// jsonArrayContains 判断 JSON 列 column 中路径 path 的数组是否包含字符串 value
+func (d Dialect) jsonArrayContains(column, path, value string) string {
+	switch d {
+	case DialectSQLite:
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, %s) WHERE json_each.value = %s)", column, path, value)
+	case DialectPostgres:
+		return fmt.Sprintf("COALESCE((%s #> CAST(%s AS TEXT[])) @> jsonb_build_array(CAST(%s AS TEXT)), FALSE)", column, path, value)
+	}
+	return fmt.Sprintf("JSON_CONTAINS(JSON_EXTRACT(%s, %s), JSON_QUOTE(%s))", column, path, value)
+}
+
+// jsonSetText 将 JSON 列 column 中路径 path 的值设置为字符串 value，返回修改后的 JSON
+func (d Dialect) jsonSetText(column, path, value string) string {
+	if d == DialectPostgres {
+		return fmt.Sprintf("jsonb_set(%s, CAST(%s AS TEXT[]), to_jsonb(CAST(%s AS TEXT)))", column, path, value)
+	}
+	return fmt.Sprintf("JSON_SET(%s, %s, %s)", column, path, value)
+}
+
+// typedParam 返回类型为 sqlType 的占位符。PostgreSQL 无法推断 SELECT 列表中参数的类型，需要显式转换
+func (d Dialect) typedParam(sqlType string) string {
+	if d == DialectPostgres {
+		return "CAST(? AS " + sqlType + ")"
+	}
+	return "?"
+}
+
+// forUpdate 在事务中锁定查询到的行。SQLite 的写事务以 IMMEDIATE 方式开始，整个数据库已被锁定
+func (d Dialect) forUpdate() string {
+	if d == DialectSQLite {
+		return ""
+	}
+	return " FOR UPDATE"
+}
+
+// insertID 执行单行 INSERT 语句并返回新行的自增ID。PostgreSQL 驱动不支持 LastInsertId，
+// 通过 RETURNING id 读取
+func (d Dialect) insertID(db execer, query string, args ...interface{}) (int64, error) {
+	if d == DialectPostgres {
+		var id int64
+		err := db.QueryRow(query+" RETURNING id", args...).Scan(&id)
+		return id, err
+	}
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// insertIDs 执行插入 rows 行的多行 INSERT 语句，按 VALUES 中的顺序返回各行的自增ID。
+// PostgreSQL 和 SQLite 通过 RETURNING id 读取：RETURNING 不保证返回顺序，但同一条语句按行顺序分配递增的ID，
+// 排序后即与 VALUES 顺序一致。MySQL 的 LAST_INSERT_ID() 为第一行的ID，后续各行按 auto_increment_increment 递增，
+// 仅在 innodb_autoinc_lock_mode 为 0 或 1 时成立，调用方需先通过 consecutiveInsertIDs 确认
+func (d Dialect) insertIDs(db execer, query string, rows int, args ...interface{}) ([]int64, error) {
+	ids := make([]int64, 0, rows)
+	if d == DialectPostgres || d == DialectSQLite {
+		result, err := db.Query(query+" RETURNING id", args...)
+		if err != nil {
+			return nil, err
+		}
+		defer result.Close()
+		for result.Next() {
+			var id int64
+			if err := result.Scan(&id); err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		if err := result.Err(); err != nil {
+			return nil, err
+		}
+		if len(ids) != rows {
+			return nil, fmt.Errorf("inserted %d rows, want %d", len(ids), rows)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids, nil
+	}
+
+	var increment int64
+	if err := db.QueryRow("SELECT @@auto_increment_increment").Scan(&increment); err != nil {
+		return nil, err
+	}
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	first, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < rows; i++ {
+		ids = append(ids, first+int64(i)*increment)
+	}
+	return ids, nil
+}
+
+// consecutiveInsertIDs 判断一条多行 INSERT 分配的自增ID能否由 insertIDs 推算。
+// MySQL 的 innodb_autoinc_lock_mode 为 2（MySQL 8.0 默认值）时并发插入的ID会交错，此时返回 false
+func (d Dialect) consecutiveInsertIDs(db execer) (bool, error) {
+	if d != DialectMySQL {
+		return true, nil
+	}
+	var mode int
+	if err := db.QueryRow("SELECT @@innodb_autoinc_lock_mode").Scan(&mode); err != nil {
+		return false, err
+	}
+	return mode != 2, nil
+}
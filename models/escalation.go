@@ -0,0 +1,166 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// EscalationPolicy 升级策略：来源接收组的通知在超时时间内未被确认时，升级通知目标接收组
+type EscalationPolicy struct {
+	ID                int64     `json:"id"`
+	TenantID          int64     `json:"-"`
+	Name              string    `json:"name"`
+	SourceGroupID     int64     `json:"sourceGroupId"` // 0 表示任意接收组
+	TargetGroupID     int64     `json:"targetGroupId"`
+	Level             int       `json:"level"` // 第几级升级，1 级作用于原始通知
+	MinSeverity       int       `json:"minSeverity"`
+	AckTimeoutMinutes int       `json:"ackTimeoutMinutes"`
+	Enabled           bool      `json:"enabled"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+// NotificationEscalation 升级记录，串联起原通知和升级后发出的通知
+type NotificationEscalation struct {
+	ID                      int64     `json:"id"`
+	NotificationID          int64     `json:"notificationId"`
+	EscalatedNotificationID int64     `json:"escalatedNotificationId"`
+	PolicyID                int64     `json:"policyId"`
+	Level                   int       `json:"level"`
+	CreatedAt               time.Time `json:"createdAt"`
+}
+
+// CreateEscalationPolicy 创建升级策略
+func CreateEscalationPolicy(db *sql.DB, policy *EscalationPolicy) error {
+	policy.CreatedAt = time.Now()
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO escalation_policies (tenant_id, name, source_group_id, target_group_id, level, min_severity,
+		ack_timeout_minutes, enabled, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		policy.TenantID, policy.Name, nullableID(policy.SourceGroupID), policy.TargetGroupID, policy.Level, policy.MinSeverity,
+		policy.AckTimeoutMinutes, policy.Enabled, policy.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating escalation policy: %v", err)
+	}
+	policy.ID = id
+	return nil
+}
+
+// UpdateEscalationPolicy 更新租户的升级策略，策略不存在或属于其他租户时返回 false
+func UpdateEscalationPolicy(db *sql.DB, policy *EscalationPolicy) (bool, error) {
+	result, err := db.Exec(
+		`UPDATE escalation_policies SET name = ?, source_group_id = ?, target_group_id = ?, level = ?,
+		min_severity = ?, ack_timeout_minutes = ?, enabled = ? WHERE id = ? AND tenant_id = ?`,
+		policy.Name, nullableID(policy.SourceGroupID), policy.TargetGroupID, policy.Level, policy.MinSeverity,
+		policy.AckTimeoutMinutes, policy.Enabled, policy.ID, policy.TenantID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error updating escalation policy: %v", err)
+	}
+	return checkTenantUpdate(db, result, "escalation_policies", policy.TenantID, policy.ID)
+}
+
+// DeleteEscalationPolicy 删除租户的升级策略，策略不存在或属于其他租户时返回 false
+func DeleteEscalationPolicy(db *sql.DB, tenantID int64, id int64) (bool, error) {
+	result, err := db.Exec("DELETE FROM escalation_policies WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting escalation policy: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// ListEscalationPolicies 获取租户的升级策略，enabledOnly 为 true 时只返回启用的策略
+func ListEscalationPolicies(db *sql.DB, tenantID int64, enabledOnly bool) ([]EscalationPolicy, error) {
+	query := `SELECT id, tenant_id, name, source_group_id, target_group_id, level, min_severity, ack_timeout_minutes,
+		enabled, created_at FROM escalation_policies WHERE tenant_id = ?`
+	if enabledOnly {
+		query += " AND enabled = TRUE"
+	}
+	query += " ORDER BY level, id"
+
+	rows, err := db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing escalation policies: %v", err)
+	}
+	defer rows.Close()
+
+	policies := []EscalationPolicy{}
+	for rows.Next() {
+		var policy EscalationPolicy
+		var sourceGroupID sql.NullInt64
+		if err := rows.Scan(&policy.ID, &policy.TenantID, &policy.Name, &sourceGroupID, &policy.TargetGroupID, &policy.Level,
+			&policy.MinSeverity, &policy.AckTimeoutMinutes, &policy.Enabled, &policy.CreatedAt); err != nil {
+			return nil, err
+		}
+		policy.SourceGroupID = sourceGroupID.Int64
+		policies = append(policies, policy)
+	}
+	return policies, rows.Err()
+}
+
+// CreateNotificationEscalation 记录一次通知升级
+func CreateNotificationEscalation(db *sql.DB, escalation *NotificationEscalation) error {
+	escalation.CreatedAt = time.Now()
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO notification_escalations (notification_id, escalated_notification_id, policy_id, level, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		escalation.NotificationID, escalation.EscalatedNotificationID, escalation.PolicyID, escalation.Level,
+		escalation.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating notification escalation: %v", err)
+	}
+	escalation.ID = id
+	return nil
+}
+
+// GetEscalationChain 获取租户下通知所在升级链上的全部升级记录，按层级排序
+func GetEscalationChain(db *sql.DB, tenantID int64, notificationID int64) ([]NotificationEscalation, error) {
+	// 先沿升级链向上找到原始通知
+	rootID := notificationID
+	for {
+		var parentID int64
+		err := db.QueryRow(
+			`SELECT notification_id FROM notification_escalations WHERE escalated_notification_id = ?
+			AND notification_id IN (SELECT id FROM notifications WHERE tenant_id = ?)`, rootID, tenantID,
+		).Scan(&parentID)
+		if err == sql.ErrNoRows {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rootID = parentID
+	}
+
+	// 再从原始通知逐级向下收集升级记录
+	chain := []NotificationEscalation{}
+	frontier := []int64{rootID}
+	for len(frontier) > 0 {
+		var next []int64
+		for _, id := range frontier {
+			rows, err := db.Query(
+				`SELECT id, notification_id, escalated_notification_id, policy_id, level, created_at
+				FROM notification_escalations WHERE notification_id = ?
+				AND notification_id IN (SELECT id FROM notifications WHERE tenant_id = ?) ORDER BY id`, id, tenantID,
+			)
+			if err != nil {
+				return nil, err
+			}
+			for rows.Next() {
+				var e NotificationEscalation
+				if err := rows.Scan(&e.ID, &e.NotificationID, &e.EscalatedNotificationID, &e.PolicyID,
+					&e.Level, &e.CreatedAt); err != nil {
+					rows.Close()
+					return nil, err
+				}
+				chain = append(chain, e)
+				next = append(next, e.EscalatedNotificationID)
+			}
+			rows.Close()
+		}
+		frontier = next
+	}
+	return chain, nil
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// IdempotencyRecord 幂等键对应的请求及其响应，StatusCode 为 0 表示请求仍在处理中
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// ReserveIdempotencyKey 为请求占用幂等键。占用成功时返回 nil；键已被使用时返回已有的记录。
+// 已过期的记录，以及 staleBefore 之前开始、仍未完成的请求（进程中途退出）会被新请求接管
+func ReserveIdempotencyKey(db *sql.DB, scope, key, requestHash string, expiresAt, staleBefore time.Time) (*IdempotencyRecord, error) {
+	now := time.Now()
+	dialect := DialectOf(db)
+	result, err := db.Exec(
+		dialect.insertIgnore()+` idempotency_keys (scope, idempotency_key, request_hash, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)`+dialect.ignoreConflicts(),
+		scope, key, requestHash, now, expiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error reserving idempotency key: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil, nil
+	}
+
+	result, err = db.Exec(
+		`UPDATE idempotency_keys SET request_hash = ?, status_code = NULL, content_type = NULL, response_body = NULL,
+		created_at = ?, expires_at = ?
+		WHERE scope = ? AND idempotency_key = ? AND (expires_at <= ? OR (status_code IS NULL AND created_at < ?))`,
+		requestHash, now, expiresAt, scope, key, now, staleBefore,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error taking over idempotency key: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil, nil
+	}
+
+	record := &IdempotencyRecord{Scope: scope, Key: key}
+	var statusCode sql.NullInt64
+	var contentType sql.NullString
+	err = db.QueryRow(
+		`SELECT request_hash, status_code, content_type, response_body, created_at, expires_at
+		FROM idempotency_keys WHERE scope = ? AND idempotency_key = ?`,
+		scope, key,
+	).Scan(&record.RequestHash, &statusCode, &contentType, &record.Body, &record.CreatedAt, &record.ExpiresAt)
+	if err == sql.ErrNoRows {
+		// 记录恰好被清理，重新占用
+		return ReserveIdempotencyKey(db, scope, key, requestHash, expiresAt, staleBefore)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting idempotency key: %v", err)
+	}
+	record.StatusCode = int(statusCode.Int64)
+	record.ContentType = contentType.String
+	return record, nil
+}
+
+// CompleteIdempotencyKey 保存请求的响应，之后使用相同幂等键的重试直接返回该响应
+func CompleteIdempotencyKey(db *sql.DB, scope, key string, statusCode int, contentType string, body []byte) error {
+	_, err := db.Exec(
+		`UPDATE idempotency_keys SET status_code = ?, content_type = ?, response_body = ?
+		WHERE scope = ? AND idempotency_key = ?`,
+		statusCode, contentType, body, scope, key,
+	)
+	if err != nil {
+		return fmt.Errorf("error completing idempotency key: %v", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey 释放幂等键，用于处理失败、允许客户端重试的请求
+func ReleaseIdempotencyKey(db *sql.DB, scope, key string) error {
+	_, err := db.Exec("DELETE FROM idempotency_keys WHERE scope = ? AND idempotency_key = ?", scope, key)
+	if err != nil {
+		return fmt.Errorf("error releasing idempotency key: %v", err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys 删除已过期的幂等键
+func DeleteExpiredIdempotencyKeys(db *sql.DB) (int64, error) {
+	result, err := db.Exec("DELETE FROM idempotency_keys WHERE expires_at <= ?", time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired idempotency keys: %v", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// 元数据字段类型
+const (
+	MetadataString      = "string"
+	MetadataNumber      = "number"
+	MetadataBoolean     = "boolean"
+	MetadataStringArray = "string[]"
+)
+
+// MetadataField 元数据字段约束
+type MetadataField struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Required bool   `json:"required"`
+}
+
+// MetadataError 元数据不是合法的 JSON 对象或不符合数据类型的约束
+type MetadataError struct {
+	Message string
+}
+
+func (e *MetadataError) Error() string {
+	return "invalid metadata: " + e.Message
+}
+
+// commonMetadataFields 所有数据类型共有的元数据字段
+var commonMetadataFields = []MetadataField{
+	{Name: "status", Kind: MetadataString}, // 由分析操作设置
+}
+
+// metadataSchemas 各数据类型的元数据约束，未列出的字段不做限制
+var metadataSchemas = map[string][]MetadataField{
+	"metrics": {
+		{Name: "metrics", Kind: MetadataStringArray, Required: true}, // 需要关注的指标名称
+	},
+	"log": {
+		{Name: "source", Kind: MetadataString},
+		{Name: "level", Kind: MetadataString},
+	},
+}
+
+// MetadataSchema 返回数据类型的元数据约束
+func MetadataSchema(recordType string) []MetadataField {
+	fields := append([]MetadataField{}, commonMetadataFields...)
+	return append(fields, metadataSchemas[recordType]...)
+}
+
+// PrepareRecordMetadata 规范化并校验记录的元数据：空值视为 {}，
+// 兼容旧客户端以字符串形式提交的 JSON 对象
+func PrepareRecordMetadata(record *DataRecord) error {
+	metadata, err := normalizeMetadata(record.Metadata)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(metadata, &fields); err != nil {
+		return &MetadataError{Message: err.Error()}
+	}
+	for _, field := range MetadataSchema(record.Type) {
+		value, ok := fields[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				return &MetadataError{Message: fmt.Sprintf("%s records require metadata field %q", record.Type, field.Name)}
+			}
+			continue
+		}
+		if !metadataKindMatches(field.Kind, value) {
+			return &MetadataError{Message: fmt.Sprintf("metadata field %q must be %s", field.Name, field.Kind)}
+		}
+	}
+
+	record.Metadata = metadata
+	return nil
+}
+
+func normalizeMetadata(metadata json.RawMessage) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(metadata)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return json.RawMessage("{}"), nil
+	}
+
+	if trimmed[0] == '"' {
+		var text string
+		if err := json.Unmarshal(trimmed, &text); err != nil {
+			return nil, &MetadataError{Message: err.Error()}
+		}
+		if text == "" {
+			return json.RawMessage("{}"), nil
+		}
+		trimmed = bytes.TrimSpace([]byte(text))
+	}
+
+	if len(trimmed) == 0 || trimmed[0] != '{' || !json.Valid(trimmed) {
+		return nil, &MetadataError{Message: "metadata must be a JSON object"}
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, trimmed); err != nil {
+		return nil, &MetadataError{Message: err.Error()}
+	}
+	return json.RawMessage(compacted.Bytes()), nil
+}
+
+func metadataKindMatches(kind string, value interface{}) bool {
+	switch kind {
+	case MetadataString:
+		_, ok := value.(string)
+		return ok
+	case MetadataNumber:
+		_, ok := value.(float64)
+		return ok
+	case MetadataBoolean:
+		_, ok := value.(bool)
+		return ok
+	case MetadataStringArray:
+		items, ok := value.([]interface{})
+		if !ok || len(items) == 0 {
+			return false
+		}
+		for _, item := range items {
+			if s, ok := item.(string); !ok || s == "" {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// MetadataEqual 比较两份元数据是否表示相同的 JSON 对象，忽略字段顺序和空白
+func MetadataEqual(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// MetricNames 返回 metrics 记录在元数据中声明的指标名称
+func MetricNames(record *DataRecord) []string {
+	var metadata struct {
+		Metrics []string `json:"metrics"`
+	}
+	if err := json.Unmarshal(record.Metadata, &metadata); err != nil {
+		return nil
+	}
+	return metadata.Metrics
+}
+
+// metadataPathPattern 元数据查询路径，如 status、source.host
+var metadataPathPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// MetadataJSONPath 将点分隔的元数据路径转换为 JSON 路径，如 source.host 转换为 $.source.host
+func MetadataJSONPath(path string) (string, error) {
+	if !metadataPathPattern.MatchString(path) {
+		return "", fmt.Errorf("invalid metadata path: %s", path)
+	}
+	return "$." + path, nil
+}
+
+// sortedMetadataPaths 按路径排序，保证生成的 SQL 稳定
+func sortedMetadataPaths(filters map[string]string) []string {
+	paths := make([]string, 0, len(filters))
+	for path := range filters {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// metadataValue 写入 JSON 列的参数；[]byte 会以二进制字符集传递，MySQL 不接受其作为 JSON
+func metadataValue(metadata json.RawMessage) string {
+	if len(metadata) == 0 {
+		return "{}"
+	}
+	return string(metadata)
+}
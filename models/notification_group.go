@@ -0,0 +1,202 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NotificationGroup 通知接收组，同一组内的接收人使用相同的通知渠道
+type NotificationGroup struct {
+	ID          int64     `json:"id"`
+	TenantID    int64     `json:"-"`
+	Name        string    `json:"name"`
+	Channel     string    `json:"channel"`
+	Recipients  []string  `json:"recipients"` // 邮箱、手机号或机器人地址，取决于渠道
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// NotificationRoute 通知路由规则，空字段表示不限制
+type NotificationRoute struct {
+	ID          int64     `json:"id"`
+	TenantID    int64     `json:"-"`
+	GroupID     int64     `json:"groupId"`
+	RecordType  string    `json:"recordType"`
+	Tag         string    `json:"tag"`
+	MinSeverity int       `json:"minSeverity"`
+	StartHour   int       `json:"startHour"` // 生效时段开始（含），0-23
+	EndHour     int       `json:"endHour"`   // 生效时段结束（不含），1-24，小于开始时间表示跨越午夜
+	Priority    int       `json:"priority"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// CreateNotificationGroup 创建通知接收组
+func CreateNotificationGroup(db *sql.DB, group *NotificationGroup) error {
+	recipients, err := json.Marshal(group.Recipients)
+	if err != nil {
+		return fmt.Errorf("error encoding recipients: %v", err)
+	}
+
+	now := time.Now()
+	group.CreatedAt = now
+	group.UpdatedAt = now
+
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO notification_groups (tenant_id, name, channel, recipients, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		group.TenantID, group.Name, group.Channel, string(recipients), group.Description, group.CreatedAt, group.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating notification group: %v", err)
+	}
+	group.ID = id
+	return nil
+}
+
+// UpdateNotificationGroup 更新租户的通知接收组，接收组不存在或属于其他租户时返回 false
+func UpdateNotificationGroup(db *sql.DB, group *NotificationGroup) (bool, error) {
+	recipients, err := json.Marshal(group.Recipients)
+	if err != nil {
+		return false, fmt.Errorf("error encoding recipients: %v", err)
+	}
+
+	group.UpdatedAt = time.Now()
+	result, err := db.Exec(
+		`UPDATE notification_groups SET name = ?, channel = ?, recipients = ?, description = ?, updated_at = ?
+		WHERE id = ? AND tenant_id = ?`,
+		group.Name, group.Channel, string(recipients), group.Description, group.UpdatedAt, group.ID, group.TenantID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error updating notification group: %v", err)
+	}
+	return checkTenantUpdate(db, result, "notification_groups", group.TenantID, group.ID)
+}
+
+// DeleteNotificationGroup 删除租户的通知接收组及其路由规则，接收组不存在或属于其他租户时返回 false
+func DeleteNotificationGroup(db *sql.DB, tenantID int64, id int64) (bool, error) {
+	result, err := db.Exec("DELETE FROM notification_groups WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting notification group: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// GetNotificationGroup 获取租户的通知接收组，不存在或属于其他租户时返回 nil
+func GetNotificationGroup(db *sql.DB, tenantID int64, id int64) (*NotificationGroup, error) {
+	row := db.QueryRow(
+		`SELECT id, tenant_id, name, channel, recipients, description, created_at, updated_at
+		FROM notification_groups WHERE id = ? AND tenant_id = ?`, id, tenantID,
+	)
+	group, err := scanNotificationGroup(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return group, err
+}
+
+// ListNotificationGroups 获取租户的所有通知接收组
+func ListNotificationGroups(db *sql.DB, tenantID int64) ([]NotificationGroup, error) {
+	rows, err := db.Query(
+		`SELECT id, tenant_id, name, channel, recipients, description, created_at, updated_at
+		FROM notification_groups WHERE tenant_id = ? ORDER BY id`, tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing notification groups: %v", err)
+	}
+	defer rows.Close()
+
+	groups := []NotificationGroup{}
+	for rows.Next() {
+		group, err := scanNotificationGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *group)
+	}
+	return groups, rows.Err()
+}
+
+func scanNotificationGroup(row interface{ Scan(...interface{}) error }) (*NotificationGroup, error) {
+	var group NotificationGroup
+	var recipients string
+	if err := row.Scan(&group.ID, &group.TenantID, &group.Name, &group.Channel, &recipients, &group.Description,
+		&group.CreatedAt, &group.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(recipients), &group.Recipients); err != nil {
+		return nil, fmt.Errorf("error decoding recipients of group %d: %v", group.ID, err)
+	}
+	return &group, nil
+}
+
+// CreateNotificationRoute 创建通知路由规则
+func CreateNotificationRoute(db *sql.DB, route *NotificationRoute) error {
+	route.CreatedAt = time.Now()
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO notification_routes (tenant_id, group_id, record_type, tag, min_severity, start_hour, end_hour,
+		priority, enabled, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		route.TenantID, route.GroupID, route.RecordType, route.Tag, route.MinSeverity, route.StartHour, route.EndHour,
+		route.Priority, route.Enabled, route.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating notification route: %v", err)
+	}
+	route.ID = id
+	return nil
+}
+
+// UpdateNotificationRoute 更新租户的通知路由规则，规则不存在或属于其他租户时返回 false
+func UpdateNotificationRoute(db *sql.DB, route *NotificationRoute) (bool, error) {
+	result, err := db.Exec(
+		`UPDATE notification_routes SET group_id = ?, record_type = ?, tag = ?, min_severity = ?,
+		start_hour = ?, end_hour = ?, priority = ?, enabled = ? WHERE id = ? AND tenant_id = ?`,
+		route.GroupID, route.RecordType, route.Tag, route.MinSeverity, route.StartHour, route.EndHour,
+		route.Priority, route.Enabled, route.ID, route.TenantID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("error updating notification route: %v", err)
+	}
+	return checkTenantUpdate(db, result, "notification_routes", route.TenantID, route.ID)
+}
+
+// DeleteNotificationRoute 删除租户的通知路由规则，规则不存在或属于其他租户时返回 false
+func DeleteNotificationRoute(db *sql.DB, tenantID int64, id int64) (bool, error) {
+	result, err := db.Exec("DELETE FROM notification_routes WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting notification route: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// ListNotificationRoutes 获取租户的路由规则，enabledOnly 为 true 时只返回启用的规则，按优先级从高到低排序
+func ListNotificationRoutes(db *sql.DB, tenantID int64, enabledOnly bool) ([]NotificationRoute, error) {
+	query := `SELECT id, tenant_id, group_id, record_type, tag, min_severity, start_hour, end_hour, priority, enabled,
+		created_at FROM notification_routes WHERE tenant_id = ?`
+	if enabledOnly {
+		query += " AND enabled = TRUE"
+	}
+	query += " ORDER BY priority DESC, id"
+
+	rows, err := db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing notification routes: %v", err)
+	}
+	defer rows.Close()
+
+	routes := []NotificationRoute{}
+	for rows.Next() {
+		var route NotificationRoute
+		if err := rows.Scan(&route.ID, &route.TenantID, &route.GroupID, &route.RecordType, &route.Tag, &route.MinSeverity,
+			&route.StartHour, &route.EndHour, &route.Priority, &route.Enabled, &route.CreatedAt); err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	return routes, rows.Err()
+}
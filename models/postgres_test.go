@@ -0,0 +1,59 @@
+package models
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRebindPostgres(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		want  string
+	}{
+		{"SELECT 1", "SELECT 1"},
+		{"SELECT * FROM tags WHERE tenant_id = ? AND tag_name = ?", "SELECT * FROM tags WHERE tenant_id = $1 AND tag_name = $2"},
+		{"SELECT '?', ? FROM t", "SELECT '?', $1 FROM t"},
+		{`SELECT "a?b" FROM t WHERE x = ?`, `SELECT "a?b" FROM t WHERE x = $1`},
+		{"SELECT 'it''s?' WHERE a = ? AND b = ?", "SELECT 'it''s?' WHERE a = $1 AND b = $2"},
+		{"UPDATE t SET a = ?, b = '数据?' WHERE c = ?", "UPDATE t SET a = $1, b = '数据?' WHERE c = $2"},
+	} {
+		if got := rebindPostgres(tc.query); got != tc.want {
+			t.Errorf("rebindPostgres(%q) = %q, want %q", tc.query, got, tc.want)
+		}
+	}
+}
+
+// TestPostgres 在 POSTGRES_TEST_DSN 指定的数据库上执行迁移和 models 测试，未设置时跳过。
+// 数据库可以重复使用，每个用例使用单独创建的租户
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+	db := openTestDB(t, dsn)
+	if dialect := DialectOf(db); dialect != DialectPostgres {
+		t.Fatalf("DialectOf = %s, want %s", dialect, DialectPostgres)
+	}
+
+	t.Run("Placeholders", func(t *testing.T) {
+		var got string
+		if err := db.QueryRow("SELECT CAST(? AS TEXT) || '?' || CAST(? AS TEXT)", "a", "b").Scan(&got); err != nil {
+			t.Fatalf("query with placeholders: %v", err)
+		}
+		if got != "a?b" {
+			t.Errorf("result = %q, want %q", got, "a?b")
+		}
+
+		stmt, err := db.Prepare("SELECT CAST(? AS INTEGER) + CAST(? AS INTEGER)")
+		if err != nil {
+			t.Fatalf("Prepare: %v", err)
+		}
+		defer stmt.Close()
+		var sum int
+		if err := stmt.QueryRow(1, 2).Scan(&sum); err != nil || sum != 3 {
+			t.Errorf("prepared statement = %d, %v; want 3", sum, err)
+		}
+	})
+
+	runModelTests(t, db)
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+)
+
+// TakeRateLimitToken 从数据库中共享的令牌桶取出一个令牌，多个实例通过行锁共享同一个桶。
+// 桶容量为 capacity，每秒补充 refill 个令牌；令牌不足时返回需要等待的时间
+func TakeRateLimitToken(db *sql.DB, key string, capacity, refill float64, now time.Time) (bool, time.Duration, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, 0, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	dialect := DialectOf(db)
+	if _, err := tx.Exec(
+		dialect.insertIgnore()+" rate_limit_buckets (bucket_key, tokens, updated_at) VALUES (?, ?, ?)"+dialect.ignoreConflicts(),
+		key, capacity, now,
+	); err != nil {
+		return false, 0, fmt.Errorf("error creating rate limit bucket: %v", err)
+	}
+
+	var tokens float64
+	var updatedAt time.Time
+	if err := tx.QueryRow(
+		"SELECT tokens, updated_at FROM rate_limit_buckets WHERE bucket_key = ?"+dialect.forUpdate(), key,
+	).Scan(&tokens, &updatedAt); err != nil {
+		return false, 0, fmt.Errorf("error locking rate limit bucket: %v", err)
+	}
+
+	if elapsed := now.Sub(updatedAt).Seconds(); elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed*refill)
+	}
+	allowed := tokens >= 1
+	var retryAfter time.Duration
+	if allowed {
+		tokens--
+	} else {
+		retryAfter = time.Duration((1 - tokens) / refill * float64(time.Second))
+	}
+
+	if _, err := tx.Exec(
+		"UPDATE rate_limit_buckets SET tokens = ?, updated_at = ? WHERE bucket_key = ?", tokens, now, key,
+	); err != nil {
+		return false, 0, fmt.Errorf("error updating rate limit bucket: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, 0, fmt.Errorf("error committing rate limit bucket: %v", err)
+	}
+	return allowed, retryAfter, nil
+}
+
+// DeleteIdleRateLimitBuckets 删除 before 之后未使用的令牌桶，这些桶早已补满，删除后行为不变
+func DeleteIdleRateLimitBuckets(db *sql.DB, before time.Time) (int64, error) {
+	result, err := db.Exec("DELETE FROM rate_limit_buckets WHERE updated_at < ?", before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting idle rate limit buckets: %v", err)
+	}
+	return result.RowsAffected()
+}
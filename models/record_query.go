@@ -0,0 +1,305 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRecordPageSize = 20
+	MaxRecordPageSize     = 100
+)
+
+// recordSortColumns 允许排序的字段
+var recordSortColumns = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// RecordFilter 数据记录查询条件，零值字段表示不限制
+type RecordFilter struct {
+	TenantID       int64 // 必填，只查询该租户的记录
+	Type           string
+	Tags           []string          // 同时带有所有标签的记录
+	Status         string            // metadata.status
+	Metadata       map[string]string // 元数据路径（如 source.host）到取值的等值条件
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	UpdatedAfter   *time.Time
+	UpdatedBefore  *time.Time
+	Analyzed       *bool
+	IncludeDeleted bool
+	SortBy         string // id、created_at 或 updated_at，默认 created_at
+	Order          string // asc 或 desc，默认 desc
+	Cursor         string // 上一页返回的 NextCursor
+	Limit          int
+
+	cursor *recordCursor
+}
+
+// RecordPage 分页查询结果
+type RecordPage struct {
+	Records    []DataRecord `json:"records"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// recordCursor 游标内容：上一页最后一条记录的排序字段值和ID
+type recordCursor struct {
+	SortBy string    `json:"s"`
+	Time   time.Time `json:"t,omitempty"`
+	ID     int64     `json:"i"`
+}
+
+// Normalize 校验查询条件并填充默认值
+func (f *RecordFilter) Normalize() error {
+	if f.TenantID == 0 {
+		return fmt.Errorf("tenant is required")
+	}
+	if f.SortBy == "" {
+		f.SortBy = "created_at"
+	}
+	if !recordSortColumns[f.SortBy] {
+		return fmt.Errorf("invalid sort field: %s", f.SortBy)
+	}
+
+	f.Order = strings.ToLower(f.Order)
+	if f.Order == "" {
+		f.Order = "desc"
+	}
+	if f.Order != "asc" && f.Order != "desc" {
+		return fmt.Errorf("invalid sort order: %s", f.Order)
+	}
+
+	for path := range f.Metadata {
+		if _, err := MetadataJSONPath(path); err != nil {
+			return err
+		}
+	}
+
+	if f.Limit <= 0 {
+		f.Limit = DefaultRecordPageSize
+	}
+	if f.Limit > MaxRecordPageSize {
+		f.Limit = MaxRecordPageSize
+	}
+
+	f.cursor = nil
+	if f.Cursor != "" {
+		cursor, err := decodeRecordCursor(f.Cursor)
+		if err != nil {
+			return err
+		}
+		if cursor.SortBy != f.SortBy {
+			return fmt.Errorf("cursor does not match sort field %s", f.SortBy)
+		}
+		f.cursor = cursor
+	}
+	return nil
+}
+
+// ListDataRecords 按条件分页查询数据记录，使用基于排序字段和ID的游标分页
+func ListDataRecords(db *sql.DB, filter RecordFilter) (*RecordPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	dialect := DialectOf(db)
+	conditions := []string{"r.tenant_id = ?"}
+	args := []interface{}{filter.TenantID}
+
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "r.deleted_at IS NULL")
+	}
+
+	if filter.Type != "" {
+		conditions = append(conditions, "r.type = ?")
+		args = append(args, filter.Type)
+	}
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM tags t WHERE t.record_id = r.id AND t.tag_name = ?)")
+		args = append(args, tag)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, dialect.jsonText("r.metadata", "?")+" = ?")
+		args = append(args, dialect.jsonPath("$.status"), filter.Status)
+	}
+	for _, path := range sortedMetadataPaths(filter.Metadata) {
+		jsonPath, err := MetadataJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+		// 标量按值比较，数组按是否包含该字符串比较
+		conditions = append(conditions,
+			"("+dialect.jsonText("r.metadata", "?")+" = ? OR "+dialect.jsonArrayContains("r.metadata", "?", "?")+")")
+		value := filter.Metadata[path]
+		jsonPath = dialect.jsonPath(jsonPath)
+		args = append(args, jsonPath, value, jsonPath, value)
+	}
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "r.created_at >= ?")
+		args = append(args, *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "r.created_at < ?")
+		args = append(args, *filter.CreatedBefore)
+	}
+	if filter.UpdatedAfter != nil {
+		conditions = append(conditions, "r.updated_at >= ?")
+		args = append(args, *filter.UpdatedAfter)
+	}
+	if filter.UpdatedBefore != nil {
+		conditions = append(conditions, "r.updated_at < ?")
+		args = append(args, *filter.UpdatedBefore)
+	}
+	if filter.Analyzed != nil {
+		exists := "EXISTS (SELECT 1 FROM analysis_results a WHERE a.record_id = r.id)"
+		if !*filter.Analyzed {
+			exists = "NOT " + exists
+		}
+		conditions = append(conditions, exists)
+	}
+
+	if cursor := filter.cursor; cursor != nil {
+		op := "<"
+		if filter.Order == "asc" {
+			op = ">"
+		}
+		if filter.SortBy == "id" {
+			conditions = append(conditions, "r.id "+op+" ?")
+			args = append(args, cursor.ID)
+		} else {
+			column := "r." + filter.SortBy
+			conditions = append(conditions, fmt.Sprintf("(%s %s ? OR (%s = ? AND r.id %s ?))", column, op, column, op))
+			args = append(args, cursor.Time, cursor.Time, cursor.ID)
+		}
+	}
+
+	query := `SELECT r.id, r.tenant_id, r.type, r.content, r.metadata, r.version, r.created_at, r.updated_at,
+		r.deleted_at, COALESCE(r.created_by, ''), COALESCE(r.updated_by, '') FROM data_records r
+		WHERE ` + strings.Join(conditions, " AND ")
+	direction := strings.ToUpper(filter.Order)
+	if filter.SortBy == "id" {
+		query += " ORDER BY r.id " + direction
+	} else {
+		query += fmt.Sprintf(" ORDER BY r.%s %s, r.id %s", filter.SortBy, direction, direction)
+	}
+	// 多取一条用于判断是否还有下一页
+	query += " LIMIT ?"
+	args = append(args, filter.Limit+1)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing data records: %v", err)
+	}
+	defer rows.Close()
+
+	page := &RecordPage{Records: []DataRecord{}}
+	for rows.Next() {
+		var record DataRecord
+		var deletedAt sql.NullTime
+		if err := rows.Scan(&record.ID, &record.TenantID, &record.Type, &record.Content, (*[]byte)(&record.Metadata), &record.Version,
+			&record.CreatedAt, &record.UpdatedAt, &deletedAt, &record.CreatedBy, &record.UpdatedBy); err != nil {
+			return nil, fmt.Errorf("error scanning data record: %v", err)
+		}
+		if deletedAt.Valid {
+			record.DeletedAt = &deletedAt.Time
+		}
+		page.Records = append(page.Records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error listing data records: %v", err)
+	}
+
+	if len(page.Records) > filter.Limit {
+		page.Records = page.Records[:filter.Limit]
+		last := page.Records[len(page.Records)-1]
+		cursor := recordCursor{SortBy: filter.SortBy, ID: last.ID}
+		switch filter.SortBy {
+		case "created_at":
+			cursor.Time = last.CreatedAt
+		case "updated_at":
+			cursor.Time = last.UpdatedAt
+		}
+		page.NextCursor = encodeRecordCursor(cursor)
+	}
+	return page, nil
+}
+
+func encodeRecordCursor(cursor recordCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeRecordCursor(value string) (*recordCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var cursor recordCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// RecordSelector 批量分析和定时规则选择记录的条件，可序列化为 JSON，字段含义同 RecordFilter
+type RecordSelector struct {
+	Type          string            `json:"type,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Status        string            `json:"status,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	CreatedAfter  *time.Time        `json:"createdAfter,omitempty"`
+	CreatedBefore *time.Time        `json:"createdBefore,omitempty"`
+	UpdatedAfter  *time.Time        `json:"updatedAfter,omitempty"`
+	UpdatedBefore *time.Time        `json:"updatedBefore,omitempty"`
+	Analyzed      *bool             `json:"analyzed,omitempty"`
+}
+
+// RecordFilter 转换为租户 tenantID 的查询条件
+func (s *RecordSelector) RecordFilter(tenantID int64) RecordFilter {
+	return RecordFilter{
+		TenantID:      tenantID,
+		Type:          s.Type,
+		Tags:          s.Tags,
+		Status:        s.Status,
+		Metadata:      s.Metadata,
+		CreatedAfter:  s.CreatedAfter,
+		CreatedBefore: s.CreatedBefore,
+		UpdatedAfter:  s.UpdatedAfter,
+		UpdatedBefore: s.UpdatedBefore,
+		Analyzed:      s.Analyzed,
+	}
+}
+
+// SelectRecordIDs 按条件查询未删除记录的ID，按ID升序，最多 limit 条。忽略 filter 中的分页和排序参数
+func SelectRecordIDs(db *sql.DB, filter RecordFilter, limit int) ([]int64, error) {
+	filter.IncludeDeleted = false
+	filter.SortBy = "id"
+	filter.Order = "asc"
+	filter.Cursor = ""
+	filter.Limit = MaxRecordPageSize
+
+	ids := []int64{}
+	for len(ids) < limit {
+		page, err := ListDataRecords(db, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range page.Records {
+			if len(ids) == limit {
+				break
+			}
+			ids = append(ids, record.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+	return ids, nil
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RecordRevision 记录内容的历史版本，每次类型、内容或元数据变化时新增一条
+type RecordRevision struct {
+	ID        int64           `json:"id"`
+	RecordID  int64           `json:"recordId"`
+	Revision  int             `json:"revision"`
+	Type      string          `json:"type"`
+	Content   string          `json:"content"`
+	Metadata  json.RawMessage `json:"metadata"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// execer 可执行 SQL 的 *sql.DB 或 *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// createRecordRevision 为记录当前内容新增一个版本，dialect 为 db 所属数据库的类型
+func createRecordRevision(db execer, dialect Dialect, record *DataRecord, createdAt time.Time) (*RecordRevision, error) {
+	var latest int
+	if err := db.QueryRow(
+		"SELECT COALESCE(MAX(revision), 0) FROM record_revisions WHERE record_id = ?", record.ID,
+	).Scan(&latest); err != nil {
+		return nil, fmt.Errorf("error getting latest revision: %v", err)
+	}
+
+	revision := &RecordRevision{
+		RecordID:  record.ID,
+		Revision:  latest + 1,
+		Type:      record.Type,
+		Content:   record.Content,
+		Metadata:  record.Metadata,
+		CreatedAt: createdAt,
+	}
+	id, err := dialect.insertID(db,
+		"INSERT INTO record_revisions (record_id, revision, type, content, metadata, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		revision.RecordID, revision.Revision, revision.Type, revision.Content, metadataValue(revision.Metadata), revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating record revision: %v", err)
+	}
+	revision.ID = id
+	return revision, nil
+}
+
+// GetLatestRecordRevision 获取租户下记录的最新版本，没有版本时返回 nil
+func GetLatestRecordRevision(db *sql.DB, tenantID int64, recordID int64) (*RecordRevision, error) {
+	revisions, err := queryRecordRevisions(db, tenantID, recordID, "ORDER BY revision DESC LIMIT 1")
+	if err != nil || len(revisions) == 0 {
+		return nil, err
+	}
+	return &revisions[0], nil
+}
+
+// ListRecordRevisions 获取租户下记录的全部版本，按版本号升序
+func ListRecordRevisions(db *sql.DB, tenantID int64, recordID int64) ([]RecordRevision, error) {
+	return queryRecordRevisions(db, tenantID, recordID, "ORDER BY revision")
+}
+
+// queryRecordRevisions 查询记录的版本，只返回属于租户 tenantID 的记录的版本
+func queryRecordRevisions(db *sql.DB, tenantID int64, recordID int64, order string) ([]RecordRevision, error) {
+	rows, err := db.Query(
+		`SELECT id, record_id, revision, type, content, metadata, created_at FROM record_revisions
+		WHERE record_id = ? AND record_id IN (SELECT id FROM data_records WHERE tenant_id = ?) `+order,
+		recordID, tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing record revisions: %v", err)
+	}
+	defer rows.Close()
+
+	revisions := []RecordRevision{}
+	for rows.Next() {
+		var revision RecordRevision
+		if err := rows.Scan(&revision.ID, &revision.RecordID, &revision.Revision, &revision.Type,
+			&revision.Content, (*[]byte)(&revision.Metadata), &revision.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning record revision: %v", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
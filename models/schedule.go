@@ -0,0 +1,157 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// 定时规则选择记录的方式
+const (
+	ScheduleModeAll        = "all"        // 每次分析所有符合条件的记录
+	ScheduleModeNew        = "new"        // 只分析上次执行以来新建的记录
+	ScheduleModeUnanalyzed = "unanalyzed" // 只分析还没有分析结果的记录
+)
+
+// Schedule 定时分析规则：按 cron 表达式周期性地为符合条件的记录创建批量分析任务
+type Schedule struct {
+	ID          int64          `json:"id"`
+	TenantID    int64          `json:"-"`
+	Name        string         `json:"name"`
+	Cron        string         `json:"cron"`
+	Mode        string         `json:"mode"`
+	Filter      RecordSelector `json:"filter"`
+	MaxRecords  int            `json:"maxRecords"` // 0 表示使用批量分析的默认上限
+	Enabled     bool           `json:"enabled"`
+	NextRunAt   *time.Time     `json:"nextRunAt,omitempty"`
+	LastRunAt   *time.Time     `json:"lastRunAt,omitempty"`
+	LastBatchID int64          `json:"lastBatchId,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// IsValidScheduleMode 判断是否为支持的记录选择方式
+func IsValidScheduleMode(mode string) bool {
+	return mode == ScheduleModeAll || mode == ScheduleModeNew || mode == ScheduleModeUnanalyzed
+}
+
+// CreateSchedule 创建定时规则
+func CreateSchedule(db *sql.DB, schedule *Schedule) error {
+	filter, err := json.Marshal(schedule.Filter)
+	if err != nil {
+		return fmt.Errorf("error encoding schedule filter: %v", err)
+	}
+
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO schedules (tenant_id, name, cron_expr, mode, filter, max_records, enabled, next_run_at,
+		created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		schedule.TenantID, schedule.Name, schedule.Cron, schedule.Mode, string(filter), schedule.MaxRecords, schedule.Enabled,
+		schedule.NextRunAt, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating schedule: %v", err)
+	}
+	schedule.ID = id
+	return nil
+}
+
+// UpdateSchedule 更新租户的定时规则
+func UpdateSchedule(db *sql.DB, schedule *Schedule) error {
+	filter, err := json.Marshal(schedule.Filter)
+	if err != nil {
+		return fmt.Errorf("error encoding schedule filter: %v", err)
+	}
+
+	schedule.UpdatedAt = time.Now()
+	_, err = db.Exec(
+		`UPDATE schedules SET name = ?, cron_expr = ?, mode = ?, filter = ?, max_records = ?, enabled = ?,
+		next_run_at = ?, updated_at = ? WHERE id = ? AND tenant_id = ?`,
+		schedule.Name, schedule.Cron, schedule.Mode, string(filter), schedule.MaxRecords, schedule.Enabled,
+		schedule.NextRunAt, schedule.UpdatedAt, schedule.ID, schedule.TenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating schedule: %v", err)
+	}
+	return nil
+}
+
+// DeleteSchedule 删除租户的定时规则
+func DeleteSchedule(db *sql.DB, tenantID int64, id int64) error {
+	if _, err := db.Exec("DELETE FROM schedules WHERE id = ? AND tenant_id = ?", id, tenantID); err != nil {
+		return fmt.Errorf("error deleting schedule: %v", err)
+	}
+	return nil
+}
+
+// GetSchedule 获取租户的定时规则，不存在或属于其他租户时返回 nil
+func GetSchedule(db *sql.DB, tenantID int64, id int64) (*Schedule, error) {
+	schedules, err := querySchedules(db, "WHERE id = ? AND tenant_id = ?", id, tenantID)
+	if err != nil || len(schedules) == 0 {
+		return nil, err
+	}
+	return &schedules[0], nil
+}
+
+// ListSchedules 获取租户的所有定时规则
+func ListSchedules(db *sql.DB, tenantID int64) ([]Schedule, error) {
+	return querySchedules(db, "WHERE tenant_id = ? ORDER BY id", tenantID)
+}
+
+// GetDueSchedules 获取所有租户已到执行时间的启用规则
+func GetDueSchedules(db *sql.DB, now time.Time) ([]Schedule, error) {
+	return querySchedules(db, "WHERE enabled = TRUE AND next_run_at <= ? ORDER BY next_run_at", now)
+}
+
+func querySchedules(db *sql.DB, where string, args ...interface{}) ([]Schedule, error) {
+	rows, err := db.Query(
+		`SELECT id, tenant_id, name, cron_expr, mode, filter, max_records, enabled, next_run_at, last_run_at,
+		last_batch_id, created_at, updated_at FROM schedules `+where,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying schedules: %v", err)
+	}
+	defer rows.Close()
+
+	schedules := []Schedule{}
+	for rows.Next() {
+		var schedule Schedule
+		var filter []byte
+		var nextRunAt, lastRunAt sql.NullTime
+		var lastBatchID sql.NullInt64
+		if err := rows.Scan(&schedule.ID, &schedule.TenantID, &schedule.Name, &schedule.Cron, &schedule.Mode, &filter,
+			&schedule.MaxRecords, &schedule.Enabled, &nextRunAt, &lastRunAt, &lastBatchID,
+			&schedule.CreatedAt, &schedule.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning schedule: %v", err)
+		}
+		if err := json.Unmarshal(filter, &schedule.Filter); err != nil {
+			return nil, fmt.Errorf("error decoding schedule filter: %v", err)
+		}
+		if nextRunAt.Valid {
+			schedule.NextRunAt = &nextRunAt.Time
+		}
+		if lastRunAt.Valid {
+			schedule.LastRunAt = &lastRunAt.Time
+		}
+		schedule.LastBatchID = lastBatchID.Int64
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+// MarkScheduleRun 记录规则的执行时间、下次执行时间和创建的批量分析任务（batchID 为 0 表示本次没有创建任务）
+func MarkScheduleRun(db *sql.DB, id int64, runAt time.Time, nextRunAt time.Time, batchID int64) error {
+	_, err := db.Exec(
+		`UPDATE schedules SET last_run_at = ?, next_run_at = ?,
+		last_batch_id = COALESCE(?, last_batch_id) WHERE id = ?`,
+		runAt, nextRunAt, nullableID(batchID), id,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating schedule run: %v", err)
+	}
+	return nil
+}
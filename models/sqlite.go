@@ -0,0 +1,80 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDefaultParams 打开 SQLite 数据库时的默认参数：开启外键约束，写事务立即加锁，
+// 并发写入时等待而不是直接返回 database is locked
+var sqliteDefaultParams = map[string]string{
+	"_foreign_keys": "1",
+	"_busy_timeout": "5000",
+	"_journal_mode": "WAL",
+	"_txlock":       "immediate",
+}
+
+// openSQLite 打开 SQLite 文件数据库，dsn 为 sqlite://<路径> 或 file:<路径>，可带查询参数
+func openSQLite(dsn string) (*sql.DB, error) {
+	if path, ok := strings.CutPrefix(dsn, sqliteDSNPrefix); ok {
+		dsn = "file:" + path
+	}
+	path, query, _ := strings.Cut(dsn, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sqlite dsn: %v", err)
+	}
+	for key, value := range sqliteDefaultParams {
+		if !params.Has(key) {
+			params.Set(key, value)
+		}
+	}
+	return sql.OpenDB(&sqliteConnector{dsn: path + "?" + params.Encode(), driver: &sqlite3.SQLiteDriver{}}), nil
+}
+
+// sqliteConnector 打开的连接把时间参数转换为 UTC 后写入。
+// SQLite 以文本保存时间并按文本比较，统一时区后比较结果才与 MySQL 一致
+type sqliteConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+}
+
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{SQLiteConn: conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type sqliteConn struct {
+	*sqlite3.SQLiteConn
+}
+
+// CheckNamedValue 将时间参数转换为 UTC，其他参数使用默认的转换
+func (c *sqliteConn) CheckNamedValue(value *driver.NamedValue) error {
+	switch v := value.Value.(type) {
+	case time.Time:
+		value.Value = v.UTC()
+		return nil
+	case *time.Time:
+		if v == nil {
+			value.Value = nil
+		} else {
+			value.Value = v.UTC()
+		}
+		return nil
+	}
+	return driver.ErrSkip
+}
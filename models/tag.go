@@ -0,0 +1,315 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// 标签来源
+const (
+	TagSourceHuman = "human" // 通过 API 由人工添加
+	TagSourceLLM   = "llm"   // 由模型建议的操作添加
+)
+
+// ErrTagNotInVocabulary 启用受控词表时，标签不在词表中
+var ErrTagNotInVocabulary = errors.New("tag is not in the controlled vocabulary")
+
+// Tag 数据标签
+type Tag struct {
+	ID        int64     `json:"id"`
+	TenantID  int64     `json:"-"`
+	RecordID  int64     `json:"record_id"`
+	TagName   string    `json:"tag_name"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TagUsage 标签使用统计
+type TagUsage struct {
+	Name         string `json:"name"`
+	Count        int    `json:"count"`
+	HumanCount   int    `json:"humanCount"`
+	LLMCount     int    `json:"llmCount"`
+	InVocabulary bool   `json:"inVocabulary"`
+}
+
+// TagAlias 标签别名，添加别名标签时会被替换为规范名称
+type TagAlias struct {
+	Alias     string    `json:"alias"`
+	TagName   string    `json:"tagName"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// VocabularyTag 受控词表中的标签
+type VocabularyTag struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// vocabularyEnforced 判断 TAG_VOCABULARY 配置是否要求该来源的标签必须在词表中：
+// off（默认）不限制，llm 只限制模型添加的标签，all 限制所有标签
+func vocabularyEnforced(source string) bool {
+	switch os.Getenv("TAG_VOCABULARY") {
+	case "all":
+		return true
+	case "llm":
+		return source == TagSourceLLM
+	default:
+		return false
+	}
+}
+
+// ResolveTagName 按租户的别名将标签解析为规范名称
+func ResolveTagName(db *sql.DB, tenantID int64, name string) (string, error) {
+	var canonical string
+	err := db.QueryRow("SELECT tag_name FROM tag_aliases WHERE tenant_id = ? AND alias = ?", tenantID, name).Scan(&canonical)
+	if err == sql.ErrNoRows {
+		return name, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return canonical, nil
+}
+
+// AddTag 为租户的记录添加标签，别名会被替换为规范名称，已存在的标签不会重复添加。
+// 记录不属于该租户时不添加。返回实际添加的标签名称
+func AddTag(db *sql.DB, tenantID int64, recordID int64, tagName string, source string) (string, error) {
+	name, err := ResolveTagName(db, tenantID, tagName)
+	if err != nil {
+		return "", fmt.Errorf("error resolving tag alias: %v", err)
+	}
+
+	if vocabularyEnforced(source) {
+		var exists bool
+		if err := db.QueryRow(
+			"SELECT EXISTS (SELECT 1 FROM tag_vocabulary WHERE tenant_id = ? AND name = ?)", tenantID, name,
+		).Scan(&exists); err != nil {
+			return "", fmt.Errorf("error checking tag vocabulary: %v", err)
+		}
+		if !exists {
+			return "", ErrTagNotInVocabulary
+		}
+	}
+
+	dialect := DialectOf(db)
+	_, err = db.Exec(
+		dialect.insertIgnore()+` tags (tenant_id, record_id, tag_name, source, created_at)
+		SELECT tenant_id, id, ?, ?, `+dialect.typedParam("TIMESTAMPTZ")+` FROM data_records WHERE id = ? AND tenant_id = ?`+
+			dialect.ignoreConflicts(),
+		name, source, time.Now(), recordID, tenantID,
+	)
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// RemoveTag 删除租户记录的标签
+func RemoveTag(db *sql.DB, tenantID int64, recordID int64, tagName string) (bool, error) {
+	result, err := db.Exec("DELETE FROM tags WHERE tenant_id = ? AND record_id = ? AND tag_name = ?", tenantID, recordID, tagName)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// GetTagsByRecordID 获取租户记录的所有标签
+func GetTagsByRecordID(db *sql.DB, tenantID int64, recordID int64) ([]Tag, error) {
+	rows, err := db.Query(
+		"SELECT id, tenant_id, record_id, tag_name, source, created_at FROM tags WHERE tenant_id = ? AND record_id = ? ORDER BY id",
+		tenantID, recordID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []Tag{}
+	for rows.Next() {
+		var tag Tag
+		if err := rows.Scan(&tag.ID, &tag.TenantID, &tag.RecordID, &tag.TagName, &tag.Source, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// ListTagUsage 统计租户所有标签的使用次数，词表中尚未使用的标签计数为 0
+func ListTagUsage(db *sql.DB, tenantID int64) ([]TagUsage, error) {
+	rows, err := db.Query(`
+		SELECT u.name, SUM(u.total), SUM(u.human), SUM(u.llm), MAX(u.in_vocabulary) FROM (
+			SELECT t.tag_name AS name, COUNT(*) AS total,
+				SUM(CASE WHEN t.source = 'human' THEN 1 ELSE 0 END) AS human,
+				SUM(CASE WHEN t.source = 'llm' THEN 1 ELSE 0 END) AS llm,
+				0 AS in_vocabulary
+			FROM tags t JOIN data_records r ON r.id = t.record_id AND r.deleted_at IS NULL
+			WHERE t.tenant_id = ?
+			GROUP BY t.tag_name
+			UNION ALL
+			SELECT name, 0, 0, 0, 1 FROM tag_vocabulary WHERE tenant_id = ?
+		) u
+		GROUP BY u.name
+		ORDER BY SUM(u.total) DESC, u.name`, tenantID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tag usage: %v", err)
+	}
+	defer rows.Close()
+
+	usages := []TagUsage{}
+	for rows.Next() {
+		var usage TagUsage
+		if err := rows.Scan(&usage.Name, &usage.Count, &usage.HumanCount, &usage.LLMCount, &usage.InVocabulary); err != nil {
+			return nil, fmt.Errorf("error scanning tag usage: %v", err)
+		}
+		usages = append(usages, usage)
+	}
+	return usages, rows.Err()
+}
+
+// MergeTags 将租户的标签 from 合并到 to：已有 from 标签的记录改为 to 标签，并将 from 登记为 to 的别名
+func MergeTags(db *sql.DB, tenantID int64, from string, to string) (int64, error) {
+	if from == to {
+		return 0, fmt.Errorf("cannot merge a tag into itself")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// 已同时带有 from 和 to 的记录无法改名（唯一索引冲突），改名后删除剩余的 from 标签
+	dialect := DialectOf(db)
+	rename := dialect.updateIgnore() + " tags SET tag_name = ? WHERE tenant_id = ? AND tag_name = ?"
+	args := []interface{}{to, tenantID, from}
+	if dialect == DialectPostgres {
+		rename += " AND NOT EXISTS (SELECT 1 FROM tags t WHERE t.record_id = tags.record_id AND t.tag_name = ?)"
+		args = append(args, to)
+	}
+	result, err := tx.Exec(rename, args...)
+	if err != nil {
+		return 0, fmt.Errorf("error renaming tags: %v", err)
+	}
+	merged, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec("DELETE FROM tags WHERE tenant_id = ? AND tag_name = ?", tenantID, from); err != nil {
+		return 0, fmt.Errorf("error removing merged tags: %v", err)
+	}
+
+	// 指向 from 的别名改为指向 to，再登记 from 本身
+	if _, err := tx.Exec(
+		"UPDATE tag_aliases SET tag_name = ? WHERE tenant_id = ? AND tag_name = ?", to, tenantID, from,
+	); err != nil {
+		return 0, fmt.Errorf("error updating tag aliases: %v", err)
+	}
+	if _, err := tx.Exec(upsertTagAliasQuery(dialect), tenantID, from, to, time.Now()); err != nil {
+		return 0, fmt.Errorf("error creating tag alias: %v", err)
+	}
+	if _, err := tx.Exec("DELETE FROM tag_vocabulary WHERE tenant_id = ? AND name = ?", tenantID, from); err != nil {
+		return 0, fmt.Errorf("error updating tag vocabulary: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return merged, nil
+}
+
+// CreateTagAlias 为租户登记标签别名
+func CreateTagAlias(db *sql.DB, tenantID int64, alias *TagAlias) error {
+	alias.CreatedAt = time.Now()
+	_, err := db.Exec(upsertTagAliasQuery(DialectOf(db)), tenantID, alias.Alias, alias.TagName, alias.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating tag alias: %v", err)
+	}
+	return nil
+}
+
+// upsertTagAliasQuery 登记别名的 SQL，别名已存在时改为指向新的标签
+func upsertTagAliasQuery(dialect Dialect) string {
+	return "INSERT INTO tag_aliases (tenant_id, alias, tag_name, created_at) VALUES (?, ?, ?, ?) " +
+		dialect.onConflictUpdate("tenant_id", "alias") + " tag_name = " + dialect.excluded("tag_name") +
+		", created_at = " + dialect.excluded("created_at")
+}
+
+// DeleteTagAlias 删除租户的标签别名
+func DeleteTagAlias(db *sql.DB, tenantID int64, alias string) error {
+	if _, err := db.Exec("DELETE FROM tag_aliases WHERE tenant_id = ? AND alias = ?", tenantID, alias); err != nil {
+		return fmt.Errorf("error deleting tag alias: %v", err)
+	}
+	return nil
+}
+
+// ListTagAliases 获取租户的所有标签别名
+func ListTagAliases(db *sql.DB, tenantID int64) ([]TagAlias, error) {
+	rows, err := db.Query(
+		"SELECT alias, tag_name, created_at FROM tag_aliases WHERE tenant_id = ? ORDER BY tag_name, alias", tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tag aliases: %v", err)
+	}
+	defer rows.Close()
+
+	aliases := []TagAlias{}
+	for rows.Next() {
+		var alias TagAlias
+		if err := rows.Scan(&alias.Alias, &alias.TagName, &alias.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning tag alias: %v", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, rows.Err()
+}
+
+// AddVocabularyTag 向租户的受控词表添加标签
+func AddVocabularyTag(db *sql.DB, tenantID int64, tag *VocabularyTag) error {
+	tag.CreatedAt = time.Now()
+	dialect := DialectOf(db)
+	_, err := db.Exec(
+		`INSERT INTO tag_vocabulary (tenant_id, name, description, created_at) VALUES (?, ?, ?, ?) `+
+			dialect.onConflictUpdate("tenant_id", "name")+` description = `+dialect.excluded("description"),
+		tenantID, tag.Name, tag.Description, tag.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error adding vocabulary tag: %v", err)
+	}
+	return nil
+}
+
+// DeleteVocabularyTag 从租户的受控词表删除标签，已添加到记录上的标签不受影响
+func DeleteVocabularyTag(db *sql.DB, tenantID int64, name string) error {
+	if _, err := db.Exec("DELETE FROM tag_vocabulary WHERE tenant_id = ? AND name = ?", tenantID, name); err != nil {
+		return fmt.Errorf("error deleting vocabulary tag: %v", err)
+	}
+	return nil
+}
+
+// ListVocabularyTags 获取租户的受控词表
+func ListVocabularyTags(db *sql.DB, tenantID int64) ([]VocabularyTag, error) {
+	rows, err := db.Query(
+		"SELECT name, description, created_at FROM tag_vocabulary WHERE tenant_id = ? ORDER BY name", tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing vocabulary tags: %v", err)
+	}
+	defer rows.Close()
+
+	tags := []VocabularyTag{}
+	for rows.Next() {
+		var tag VocabularyTag
+		if err := rows.Scan(&tag.Name, &tag.Description, &tag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning vocabulary tag: %v", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
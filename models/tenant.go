@@ -0,0 +1,184 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultTenantID 默认租户，启用多租户前的数据都属于该租户
+const DefaultTenantID int64 = 1
+
+// ErrTenantBudgetExceeded 租户本月的 DeepSeek token 用量已达到预算
+var ErrTenantBudgetExceeded = errors.New("tenant monthly token budget exceeded")
+
+// Tenant 租户，共享同一部署的团队之间的记录和配置相互隔离
+type Tenant struct {
+	ID                 int64             `json:"id"`
+	Slug               string            `json:"slug"`
+	Name               string            `json:"name"`
+	DeepSeekAPIKey     string            `json:"-"`                 // 为空时使用 DEEPSEEK_API_KEY
+	HasDeepSeekAPIKey  bool              `json:"hasDeepSeekApiKey"` // 是否配置了租户自己的 API Key
+	PromptTemplates    map[string]string `json:"promptTemplates"`   // 数据类型（或 system）到提示词模板的覆盖
+	MonthlyTokenBudget int64             `json:"monthlyTokenBudget"`
+	CreatedAt          time.Time         `json:"createdAt"`
+	UpdatedAt          time.Time         `json:"updatedAt"`
+}
+
+// TenantUsage 租户某月的 DeepSeek 调用量
+type TenantUsage struct {
+	Month    string `json:"month"` // YYYY-MM
+	Requests int    `json:"requests"`
+	Tokens   int64  `json:"tokens"`
+}
+
+// usageMonth 用量统计使用的月份
+func usageMonth(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// CreateTenant 创建租户
+func CreateTenant(db *sql.DB, tenant *Tenant) error {
+	templates, err := json.Marshal(tenant.PromptTemplates)
+	if err != nil {
+		return fmt.Errorf("error encoding prompt templates: %v", err)
+	}
+
+	now := time.Now()
+	tenant.CreatedAt = now
+	tenant.UpdatedAt = now
+	id, err := DialectOf(db).insertID(db,
+		`INSERT INTO tenants (slug, name, deepseek_api_key, prompt_templates, monthly_token_budget, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		tenant.Slug, tenant.Name, nullableString(tenant.DeepSeekAPIKey), string(templates),
+		tenant.MonthlyTokenBudget, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating tenant: %v", err)
+	}
+	tenant.ID = id
+	tenant.HasDeepSeekAPIKey = tenant.DeepSeekAPIKey != ""
+	return nil
+}
+
+// UpdateTenant 更新租户的名称、DeepSeek API Key、提示词模板和预算
+func UpdateTenant(db *sql.DB, tenant *Tenant) error {
+	templates, err := json.Marshal(tenant.PromptTemplates)
+	if err != nil {
+		return fmt.Errorf("error encoding prompt templates: %v", err)
+	}
+
+	tenant.UpdatedAt = time.Now()
+	_, err = db.Exec(
+		`UPDATE tenants SET name = ?, deepseek_api_key = ?, prompt_templates = ?, monthly_token_budget = ?,
+		updated_at = ? WHERE id = ?`,
+		tenant.Name, nullableString(tenant.DeepSeekAPIKey), string(templates), tenant.MonthlyTokenBudget,
+		tenant.UpdatedAt, tenant.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating tenant: %v", err)
+	}
+	tenant.HasDeepSeekAPIKey = tenant.DeepSeekAPIKey != ""
+	return nil
+}
+
+// GetTenant 获取租户，不存在时返回 nil
+func GetTenant(db *sql.DB, id int64) (*Tenant, error) {
+	tenants, err := queryTenants(db, "WHERE id = ?", id)
+	if err != nil || len(tenants) == 0 {
+		return nil, err
+	}
+	return &tenants[0], nil
+}
+
+// GetTenantBySlug 根据标识获取租户，不存在时返回 nil
+func GetTenantBySlug(db *sql.DB, slug string) (*Tenant, error) {
+	tenants, err := queryTenants(db, "WHERE slug = ?", slug)
+	if err != nil || len(tenants) == 0 {
+		return nil, err
+	}
+	return &tenants[0], nil
+}
+
+// ListTenants 获取所有租户
+func ListTenants(db *sql.DB) ([]Tenant, error) {
+	return queryTenants(db, "ORDER BY id")
+}
+
+func queryTenants(db *sql.DB, where string, args ...interface{}) ([]Tenant, error) {
+	rows, err := db.Query(
+		`SELECT id, slug, name, COALESCE(deepseek_api_key, ''), prompt_templates, monthly_token_budget,
+		created_at, updated_at FROM tenants `+where,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying tenants: %v", err)
+	}
+	defer rows.Close()
+
+	tenants := []Tenant{}
+	for rows.Next() {
+		var tenant Tenant
+		var templates []byte
+		if err := rows.Scan(&tenant.ID, &tenant.Slug, &tenant.Name, &tenant.DeepSeekAPIKey, &templates,
+			&tenant.MonthlyTokenBudget, &tenant.CreatedAt, &tenant.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning tenant: %v", err)
+		}
+		if len(templates) > 0 {
+			if err := json.Unmarshal(templates, &tenant.PromptTemplates); err != nil {
+				return nil, fmt.Errorf("error decoding prompt templates of tenant %d: %v", tenant.ID, err)
+			}
+		}
+		if tenant.PromptTemplates == nil {
+			tenant.PromptTemplates = map[string]string{}
+		}
+		tenant.HasDeepSeekAPIKey = tenant.DeepSeekAPIKey != ""
+		tenants = append(tenants, tenant)
+	}
+	return tenants, rows.Err()
+}
+
+// GetTenantUsage 获取租户当月的 DeepSeek 调用量
+func GetTenantUsage(db *sql.DB, tenantID int64) (*TenantUsage, error) {
+	usage := &TenantUsage{Month: usageMonth(time.Now())}
+	err := db.QueryRow(
+		"SELECT requests, tokens FROM tenant_usage WHERE tenant_id = ? AND month = ?", tenantID, usage.Month,
+	).Scan(&usage.Requests, &usage.Tokens)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("error getting tenant usage: %v", err)
+	}
+	return usage, nil
+}
+
+// CheckTenantBudget 租户本月用量已达到预算时返回 ErrTenantBudgetExceeded，预算为 0 表示不限制
+func CheckTenantBudget(db *sql.DB, tenant *Tenant) error {
+	if tenant.MonthlyTokenBudget <= 0 {
+		return nil
+	}
+	usage, err := GetTenantUsage(db, tenant.ID)
+	if err != nil {
+		return err
+	}
+	if usage.Tokens >= tenant.MonthlyTokenBudget {
+		return ErrTenantBudgetExceeded
+	}
+	return nil
+}
+
+// AddTenantUsage 累加租户当月的 DeepSeek 调用次数和 token 用量
+func AddTenantUsage(db *sql.DB, tenantID int64, tokens int64) error {
+	now := time.Now()
+	dialect := DialectOf(db)
+	_, err := db.Exec(
+		`INSERT INTO tenant_usage (tenant_id, month, requests, tokens, updated_at) VALUES (?, ?, 1, ?, ?) `+
+			dialect.onConflictUpdate("tenant_id", "month")+` requests = tenant_usage.requests + 1, tokens = tenant_usage.tokens + `+
+			dialect.excluded("tokens")+`, updated_at = `+dialect.excluded("updated_at"),
+		tenantID, usageMonth(now), tokens, now,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating tenant usage: %v", err)
+	}
+	return nil
+}
@@ -58,6 +58,7 @@ func DefaultTemplates() []*PromptTemplate {
     "analysis": "这里是分析结果文本",
     "suggestions": ["建议1", "建议2", "建议3"],
     "confidence": 0.95,
+    "severity": 3,
     "actions": [
         {
             "type": "database",
@@ -74,8 +75,7 @@ func DefaultTemplates() []*PromptTemplate {
             "target": "发送通知",
             "params": {
                 "record_id": 123,
-                "message": "通知内容",
-                "channel": "通知渠道"
+                "message": "通知内容"
             },
             "priority": 2
         },
@@ -96,7 +96,9 @@ func DefaultTemplates() []*PromptTemplate {
 3. suggestions 必须是字符串数组
 4. actions 中的每个操作都必须包含 type、target、params、priority 字段
 5. record_id 必须是数值类型
-6. 每种操作类型都有其特定的参数要求，请严格按照示例格式提供`,
+6. 每种操作类型都有其特定的参数要求，请严格按照示例格式提供
+7. severity 必须是 1-5 之间的整数，表示问题严重程度，5 为最严重
+8. 通知操作只需给出通知内容，通知渠道和接收人由系统根据路由规则决定`,
 			Placeholder: []string{"%DATA%"},
 		},
 		{
@@ -0,0 +1,2178 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: analysis.proto
+
+// 数据分析 gRPC 接口，与 HTTP 接口共用同一套业务逻辑。
+// 认证方式与 HTTP 接口相同：在 metadata 中传入 authorization: Bearer <key> 或 x-api-key
+
+package analysispb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Record struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Version       int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,10,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_analysis_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Record) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Record) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Record) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Record) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Record) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Record) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Record) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Record) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Record) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Record) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+// AutoAnalysis 创建或修改记录时按 AUTO_ANALYZE 配置自动分析的情况
+type AutoAnalysis struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	AnalysisId    int64                  `protobuf:"varint,2,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	BatchId       int64                  `protobuf:"varint,3,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoAnalysis) Reset() {
+	*x = AutoAnalysis{}
+	mi := &file_analysis_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoAnalysis) ProtoMessage() {}
+
+func (x *AutoAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoAnalysis.ProtoReflect.Descriptor instead.
+func (*AutoAnalysis) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AutoAnalysis) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *AutoAnalysis) GetAnalysisId() int64 {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return 0
+}
+
+func (x *AutoAnalysis) GetBatchId() int64 {
+	if x != nil {
+		return x.BatchId
+	}
+	return 0
+}
+
+func (x *AutoAnalysis) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        *Record                `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	AutoAnalysis  *AutoAnalysis          `protobuf:"bytes,2,opt,name=auto_analysis,json=autoAnalysis,proto3" json:"auto_analysis,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordResponse) Reset() {
+	*x = RecordResponse{}
+	mi := &file_analysis_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordResponse) ProtoMessage() {}
+
+func (x *RecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordResponse.ProtoReflect.Descriptor instead.
+func (*RecordResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecordResponse) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RecordResponse) GetAutoAnalysis() *AutoAnalysis {
+	if x != nil {
+		return x.AutoAnalysis
+	}
+	return nil
+}
+
+type CreateRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRecordRequest) Reset() {
+	*x = CreateRecordRequest{}
+	mi := &file_analysis_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecordRequest) ProtoMessage() {}
+
+func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecordRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateRecordRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateRecordRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreateRecordRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetRecordRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetRecordRequest) Reset() {
+	*x = GetRecordRequest{}
+	mi := &file_analysis_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordRequest) ProtoMessage() {}
+
+func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRecordRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetRecordRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+// RecordFilter 记录的筛选条件，含义同 HTTP 接口的查询参数
+type RecordFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	Analyzed      *bool                  `protobuf:"varint,9,opt,name=analyzed,proto3,oneof" json:"analyzed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordFilter) Reset() {
+	*x = RecordFilter{}
+	mi := &file_analysis_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordFilter) ProtoMessage() {}
+
+func (x *RecordFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordFilter.ProtoReflect.Descriptor instead.
+func (*RecordFilter) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecordFilter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RecordFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RecordFilter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RecordFilter) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RecordFilter) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *RecordFilter) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *RecordFilter) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *RecordFilter) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *RecordFilter) GetAnalyzed() bool {
+	if x != nil && x.Analyzed != nil {
+		return *x.Analyzed
+	}
+	return false
+}
+
+type ListRecordsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Filter         *RecordFilter          `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Sort           string                 `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Order          string                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	Cursor         string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit          int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListRecordsRequest) Reset() {
+	*x = ListRecordsRequest{}
+	mi := &file_analysis_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsRequest) ProtoMessage() {}
+
+func (x *ListRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRecordsRequest) GetFilter() *RecordFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListRecordsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRecordsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*Record              `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecordsResponse) Reset() {
+	*x = ListRecordsResponse{}
+	mi := &file_analysis_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsResponse) ProtoMessage() {}
+
+func (x *ListRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRecordsResponse) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListRecordsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// UpdateRecordRequest 只修改提供的字段，expected_version 必填，需要与记录当前版本一致
+type UpdateRecordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            *string                `protobuf:"bytes,2,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	Content         *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	Metadata        *structpb.Struct       `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRecordRequest) Reset() {
+	*x = UpdateRecordRequest{}
+	mi := &file_analysis_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRecordRequest) ProtoMessage() {}
+
+func (x *UpdateRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRecordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRecordRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateRecordRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRecordRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *UpdateRecordRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *UpdateRecordRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateRecordRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteRecordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteRecordRequest) Reset() {
+	*x = DeleteRecordRequest{}
+	mi := &file_analysis_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRecordRequest) ProtoMessage() {}
+
+func (x *DeleteRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRecordRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRecordRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteRecordRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteRecordRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteRecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRecordResponse) Reset() {
+	*x = DeleteRecordResponse{}
+	mi := &file_analysis_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRecordResponse) ProtoMessage() {}
+
+func (x *DeleteRecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRecordResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRecordResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{10}
+}
+
+type RestoreRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreRecordRequest) Reset() {
+	*x = RestoreRecordRequest{}
+	mi := &file_analysis_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRecordRequest) ProtoMessage() {}
+
+func (x *RestoreRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRecordRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRecordRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestoreRecordRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ActionExecution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RecordId      int64                  `protobuf:"varint,2,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	AnalysisId    int64                  `protobuf:"varint,3,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Target        string                 `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	Params        *structpb.Struct       `protobuf:"bytes,6,opt,name=params,proto3" json:"params,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	ExecutedBy    string                 `protobuf:"bytes,9,opt,name=executed_by,json=executedBy,proto3" json:"executed_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionExecution) Reset() {
+	*x = ActionExecution{}
+	mi := &file_analysis_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionExecution) ProtoMessage() {}
+
+func (x *ActionExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionExecution.ProtoReflect.Descriptor instead.
+func (*ActionExecution) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ActionExecution) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ActionExecution) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *ActionExecution) GetAnalysisId() int64 {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return 0
+}
+
+func (x *ActionExecution) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ActionExecution) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ActionExecution) GetParams() *structpb.Struct {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *ActionExecution) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ActionExecution) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ActionExecution) GetExecutedBy() string {
+	if x != nil {
+		return x.ExecutedBy
+	}
+	return ""
+}
+
+func (x *ActionExecution) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListRecordActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      int64                  `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecordActionsRequest) Reset() {
+	*x = ListRecordActionsRequest{}
+	mi := &file_analysis_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordActionsRequest) ProtoMessage() {}
+
+func (x *ListRecordActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecordActionsRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListRecordActionsRequest) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+type ListRecordActionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actions       []*ActionExecution     `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecordActionsResponse) Reset() {
+	*x = ListRecordActionsResponse{}
+	mi := &file_analysis_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordActionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordActionsResponse) ProtoMessage() {}
+
+func (x *ListRecordActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordActionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecordActionsResponse) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListRecordActionsResponse) GetActions() []*ActionExecution {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type AnalysisResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RecordId      int64                  `protobuf:"varint,2,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	RevisionId    int64                  `protobuf:"varint,3,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	Revision      int32                  `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	Analysis      string                 `protobuf:"bytes,5,opt,name=analysis,proto3" json:"analysis,omitempty"`
+	Suggestions   []string               `protobuf:"bytes,6,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,7,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Severity      int32                  `protobuf:"varint,8,opt,name=severity,proto3" json:"severity,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalysisResult) Reset() {
+	*x = AnalysisResult{}
+	mi := &file_analysis_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisResult) ProtoMessage() {}
+
+func (x *AnalysisResult) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisResult.ProtoReflect.Descriptor instead.
+func (*AnalysisResult) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AnalysisResult) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetRevisionId() int64 {
+	if x != nil {
+		return x.RevisionId
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetAnalysis() string {
+	if x != nil {
+		return x.Analysis
+	}
+	return ""
+}
+
+func (x *AnalysisResult) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *AnalysisResult) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetSeverity() int32 {
+	if x != nil {
+		return x.Severity
+	}
+	return 0
+}
+
+func (x *AnalysisResult) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *AnalysisResult) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AnalyzeRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      int64                  `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRecordRequest) Reset() {
+	*x = AnalyzeRecordRequest{}
+	mi := &file_analysis_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRecordRequest) ProtoMessage() {}
+
+func (x *AnalyzeRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRecordRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRecordRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AnalyzeRecordRequest) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+type AnalyzeRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordIds     []int64                `protobuf:"varint,1,rep,packed,name=record_ids,json=recordIds,proto3" json:"record_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRecordsRequest) Reset() {
+	*x = AnalyzeRecordsRequest{}
+	mi := &file_analysis_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRecordsRequest) ProtoMessage() {}
+
+func (x *AnalyzeRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRecordsRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AnalyzeRecordsRequest) GetRecordIds() []int64 {
+	if x != nil {
+		return x.RecordIds
+	}
+	return nil
+}
+
+// Error 单条记录分析失败的原因，code 与 HTTP 接口的错误码相同
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_analysis_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AnalyzeRecordsEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RecordId int64                  `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	// Types that are valid to be assigned to Outcome:
+	//
+	//	*AnalyzeRecordsEvent_Result
+	//	*AnalyzeRecordsEvent_Error
+	Outcome       isAnalyzeRecordsEvent_Outcome `protobuf_oneof:"outcome"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRecordsEvent) Reset() {
+	*x = AnalyzeRecordsEvent{}
+	mi := &file_analysis_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRecordsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRecordsEvent) ProtoMessage() {}
+
+func (x *AnalyzeRecordsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRecordsEvent.ProtoReflect.Descriptor instead.
+func (*AnalyzeRecordsEvent) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AnalyzeRecordsEvent) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *AnalyzeRecordsEvent) GetOutcome() isAnalyzeRecordsEvent_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+func (x *AnalyzeRecordsEvent) GetResult() *AnalysisResult {
+	if x != nil {
+		if x, ok := x.Outcome.(*AnalyzeRecordsEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *AnalyzeRecordsEvent) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Outcome.(*AnalyzeRecordsEvent_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isAnalyzeRecordsEvent_Outcome interface {
+	isAnalyzeRecordsEvent_Outcome()
+}
+
+type AnalyzeRecordsEvent_Result struct {
+	Result *AnalysisResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+type AnalyzeRecordsEvent_Error struct {
+	Error *Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*AnalyzeRecordsEvent_Result) isAnalyzeRecordsEvent_Outcome() {}
+
+func (*AnalyzeRecordsEvent_Error) isAnalyzeRecordsEvent_Outcome() {}
+
+type AnalysisBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Done          int32                  `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Remaining     int32                  `protobuf:"varint,6,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalysisBatch) Reset() {
+	*x = AnalysisBatch{}
+	mi := &file_analysis_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisBatch) ProtoMessage() {}
+
+func (x *AnalysisBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisBatch.ProtoReflect.Descriptor instead.
+func (*AnalysisBatch) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AnalysisBatch) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AnalysisBatch) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AnalysisBatch) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AnalysisBatch) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *AnalysisBatch) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *AnalysisBatch) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *AnalysisBatch) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *AnalysisBatch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AnalysisBatch) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *AnalysisBatch) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type AnalysisBatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      int64                  `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ResultId      int64                  `protobuf:"varint,3,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalysisBatchItem) Reset() {
+	*x = AnalysisBatchItem{}
+	mi := &file_analysis_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisBatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisBatchItem) ProtoMessage() {}
+
+func (x *AnalysisBatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisBatchItem.ProtoReflect.Descriptor instead.
+func (*AnalysisBatchItem) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AnalysisBatchItem) GetRecordId() int64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *AnalysisBatchItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AnalysisBatchItem) GetResultId() int64 {
+	if x != nil {
+		return x.ResultId
+	}
+	return 0
+}
+
+func (x *AnalysisBatchItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AnalysisBatchItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type AnalysisBatchReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Succeeded         int32                  `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed            int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	AverageConfidence float64                `protobuf:"fixed64,3,opt,name=average_confidence,json=averageConfidence,proto3" json:"average_confidence,omitempty"`
+	SeverityCounts    map[int32]int32        `protobuf:"bytes,4,rep,name=severity_counts,json=severityCounts,proto3" json:"severity_counts,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Failures          []*AnalysisBatchItem   `protobuf:"bytes,5,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AnalysisBatchReport) Reset() {
+	*x = AnalysisBatchReport{}
+	mi := &file_analysis_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisBatchReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisBatchReport) ProtoMessage() {}
+
+func (x *AnalysisBatchReport) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisBatchReport.ProtoReflect.Descriptor instead.
+func (*AnalysisBatchReport) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AnalysisBatchReport) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *AnalysisBatchReport) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *AnalysisBatchReport) GetAverageConfidence() float64 {
+	if x != nil {
+		return x.AverageConfidence
+	}
+	return 0
+}
+
+func (x *AnalysisBatchReport) GetSeverityCounts() map[int32]int32 {
+	if x != nil {
+		return x.SeverityCounts
+	}
+	return nil
+}
+
+func (x *AnalysisBatchReport) GetFailures() []*AnalysisBatchItem {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// AnalysisBatchStatus 任务结束（completed 或 cancelled）后附带汇总报告
+type AnalysisBatchStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Batch         *AnalysisBatch         `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	Report        *AnalysisBatchReport   `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalysisBatchStatus) Reset() {
+	*x = AnalysisBatchStatus{}
+	mi := &file_analysis_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisBatchStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisBatchStatus) ProtoMessage() {}
+
+func (x *AnalysisBatchStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisBatchStatus.ProtoReflect.Descriptor instead.
+func (*AnalysisBatchStatus) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AnalysisBatchStatus) GetBatch() *AnalysisBatch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *AnalysisBatchStatus) GetReport() *AnalysisBatchReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// CreateAnalysisBatchRequest record_ids 和 filter 二选一
+type CreateAnalysisBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordIds     []int64                `protobuf:"varint,1,rep,packed,name=record_ids,json=recordIds,proto3" json:"record_ids,omitempty"`
+	Filter        *RecordFilter          `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAnalysisBatchRequest) Reset() {
+	*x = CreateAnalysisBatchRequest{}
+	mi := &file_analysis_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAnalysisBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAnalysisBatchRequest) ProtoMessage() {}
+
+func (x *CreateAnalysisBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAnalysisBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateAnalysisBatchRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateAnalysisBatchRequest) GetRecordIds() []int64 {
+	if x != nil {
+		return x.RecordIds
+	}
+	return nil
+}
+
+func (x *CreateAnalysisBatchRequest) GetFilter() *RecordFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CreateAnalysisBatchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetAnalysisBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnalysisBatchRequest) Reset() {
+	*x = GetAnalysisBatchRequest{}
+	mi := &file_analysis_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnalysisBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnalysisBatchRequest) ProtoMessage() {}
+
+func (x *GetAnalysisBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnalysisBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetAnalysisBatchRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetAnalysisBatchRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CancelAnalysisBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAnalysisBatchRequest) Reset() {
+	*x = CancelAnalysisBatchRequest{}
+	mi := &file_analysis_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAnalysisBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAnalysisBatchRequest) ProtoMessage() {}
+
+func (x *CancelAnalysisBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analysis_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAnalysisBatchRequest.ProtoReflect.Descriptor instead.
+func (*CancelAnalysisBatchRequest) Descriptor() ([]byte, []int) {
+	return file_analysis_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CancelAnalysisBatchRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_analysis_proto protoreflect.FileDescriptor
+
+const file_analysis_proto_rawDesc = "" +
+	"\n" +
+	"\x0eanalysis.proto\x12\x14deepseek.analysis.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\x03\n" +
+	"\x06Record\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x123\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\n" +
+	" \x01(\tR\tupdatedBy\"t\n" +
+	"\fAutoAnalysis\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x1f\n" +
+	"\vanalysis_id\x18\x02 \x01(\x03R\n" +
+	"analysisId\x12\x19\n" +
+	"\bbatch_id\x18\x03 \x01(\x03R\abatchId\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x8f\x01\n" +
+	"\x0eRecordResponse\x124\n" +
+	"\x06record\x18\x01 \x01(\v2\x1c.deepseek.analysis.v1.RecordR\x06record\x12G\n" +
+	"\rauto_analysis\x18\x02 \x01(\v2\".deepseek.analysis.v1.AutoAnalysisR\fautoAnalysis\"x\n" +
+	"\x13CreateRecordRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x123\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"K\n" +
+	"\x10GetRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"\x8f\x04\n" +
+	"\fRecordFilter\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12L\n" +
+	"\bmetadata\x18\x04 \x03(\v20.deepseek.analysis.v1.RecordFilter.MetadataEntryR\bmetadata\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\x12A\n" +
+	"\x0eupdated_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12\x1f\n" +
+	"\banalyzed\x18\t \x01(\bH\x00R\banalyzed\x88\x01\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_analyzed\"\xd1\x01\n" +
+	"\x12ListRecordsRequest\x12:\n" +
+	"\x06filter\x18\x01 \x01(\v2\".deepseek.analysis.v1.RecordFilterR\x06filter\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x03 \x01(\tR\x05order\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12'\n" +
+	"\x0finclude_deleted\x18\x06 \x01(\bR\x0eincludeDeleted\"n\n" +
+	"\x13ListRecordsResponse\x126\n" +
+	"\arecords\x18\x01 \x03(\v2\x1c.deepseek.analysis.v1.RecordR\arecords\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xd2\x01\n" +
+	"\x13UpdateRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04type\x18\x02 \x01(\tH\x00R\x04type\x88\x01\x01\x12\x1d\n" +
+	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x123\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12)\n" +
+	"\x10expected_version\x18\x05 \x01(\x05R\x0fexpectedVersionB\a\n" +
+	"\x05_typeB\n" +
+	"\n" +
+	"\b_content\"P\n" +
+	"\x13DeleteRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion\"\x16\n" +
+	"\x14DeleteRecordResponse\"&\n" +
+	"\x14RestoreRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xc6\x02\n" +
+	"\x0fActionExecution\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\trecord_id\x18\x02 \x01(\x03R\brecordId\x12\x1f\n" +
+	"\vanalysis_id\x18\x03 \x01(\x03R\n" +
+	"analysisId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x12/\n" +
+	"\x06params\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06params\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x1f\n" +
+	"\vexecuted_by\x18\t \x01(\tR\n" +
+	"executedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"7\n" +
+	"\x18ListRecordActionsRequest\x12\x1b\n" +
+	"\trecord_id\x18\x01 \x01(\x03R\brecordId\"\\\n" +
+	"\x19ListRecordActionsResponse\x12?\n" +
+	"\aactions\x18\x01 \x03(\v2%.deepseek.analysis.v1.ActionExecutionR\aactions\"\xce\x02\n" +
+	"\x0eAnalysisResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\trecord_id\x18\x02 \x01(\x03R\brecordId\x12\x1f\n" +
+	"\vrevision_id\x18\x03 \x01(\x03R\n" +
+	"revisionId\x12\x1a\n" +
+	"\brevision\x18\x04 \x01(\x05R\brevision\x12\x1a\n" +
+	"\banalysis\x18\x05 \x01(\tR\banalysis\x12 \n" +
+	"\vsuggestions\x18\x06 \x03(\tR\vsuggestions\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\a \x01(\x01R\n" +
+	"confidence\x12\x1a\n" +
+	"\bseverity\x18\b \x01(\x05R\bseverity\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"3\n" +
+	"\x14AnalyzeRecordRequest\x12\x1b\n" +
+	"\trecord_id\x18\x01 \x01(\x03R\brecordId\"6\n" +
+	"\x15AnalyzeRecordsRequest\x12\x1d\n" +
+	"\n" +
+	"record_ids\x18\x01 \x03(\x03R\trecordIds\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb2\x01\n" +
+	"\x13AnalyzeRecordsEvent\x12\x1b\n" +
+	"\trecord_id\x18\x01 \x01(\x03R\brecordId\x12>\n" +
+	"\x06result\x18\x02 \x01(\v2$.deepseek.analysis.v1.AnalysisResultH\x00R\x06result\x123\n" +
+	"\x05error\x18\x03 \x01(\v2\x1b.deepseek.analysis.v1.ErrorH\x00R\x05errorB\t\n" +
+	"\aoutcome\"\xe9\x02\n" +
+	"\rAnalysisBatch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\x05R\x04done\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\x12\x1c\n" +
+	"\tremaining\x18\x06 \x01(\x05R\tremaining\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\xb6\x01\n" +
+	"\x11AnalysisBatchItem\x12\x1b\n" +
+	"\trecord_id\x18\x01 \x01(\x03R\brecordId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\tresult_id\x18\x03 \x01(\x03R\bresultId\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xea\x02\n" +
+	"\x13AnalysisBatchReport\x12\x1c\n" +
+	"\tsucceeded\x18\x01 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12-\n" +
+	"\x12average_confidence\x18\x03 \x01(\x01R\x11averageConfidence\x12f\n" +
+	"\x0fseverity_counts\x18\x04 \x03(\v2=.deepseek.analysis.v1.AnalysisBatchReport.SeverityCountsEntryR\x0eseverityCounts\x12C\n" +
+	"\bfailures\x18\x05 \x03(\v2'.deepseek.analysis.v1.AnalysisBatchItemR\bfailures\x1aA\n" +
+	"\x13SeverityCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x93\x01\n" +
+	"\x13AnalysisBatchStatus\x129\n" +
+	"\x05batch\x18\x01 \x01(\v2#.deepseek.analysis.v1.AnalysisBatchR\x05batch\x12A\n" +
+	"\x06report\x18\x02 \x01(\v2).deepseek.analysis.v1.AnalysisBatchReportR\x06report\"\x8d\x01\n" +
+	"\x1aCreateAnalysisBatchRequest\x12\x1d\n" +
+	"\n" +
+	"record_ids\x18\x01 \x03(\x03R\trecordIds\x12:\n" +
+	"\x06filter\x18\x02 \x01(\v2\".deepseek.analysis.v1.RecordFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\")\n" +
+	"\x17GetAnalysisBatchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\",\n" +
+	"\x1aCancelAnalysisBatchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id2\xca\n" +
+	"\n" +
+	"\fDataAnalysis\x12_\n" +
+	"\fCreateRecord\x12).deepseek.analysis.v1.CreateRecordRequest\x1a$.deepseek.analysis.v1.RecordResponse\x12Q\n" +
+	"\tGetRecord\x12&.deepseek.analysis.v1.GetRecordRequest\x1a\x1c.deepseek.analysis.v1.Record\x12b\n" +
+	"\vListRecords\x12(.deepseek.analysis.v1.ListRecordsRequest\x1a).deepseek.analysis.v1.ListRecordsResponse\x12_\n" +
+	"\fUpdateRecord\x12).deepseek.analysis.v1.UpdateRecordRequest\x1a$.deepseek.analysis.v1.RecordResponse\x12e\n" +
+	"\fDeleteRecord\x12).deepseek.analysis.v1.DeleteRecordRequest\x1a*.deepseek.analysis.v1.DeleteRecordResponse\x12Y\n" +
+	"\rRestoreRecord\x12*.deepseek.analysis.v1.RestoreRecordRequest\x1a\x1c.deepseek.analysis.v1.Record\x12t\n" +
+	"\x11ListRecordActions\x12..deepseek.analysis.v1.ListRecordActionsRequest\x1a/.deepseek.analysis.v1.ListRecordActionsResponse\x12a\n" +
+	"\rAnalyzeRecord\x12*.deepseek.analysis.v1.AnalyzeRecordRequest\x1a$.deepseek.analysis.v1.AnalysisResult\x12j\n" +
+	"\x0eAnalyzeRecords\x12+.deepseek.analysis.v1.AnalyzeRecordsRequest\x1a).deepseek.analysis.v1.AnalyzeRecordsEvent0\x01\x12l\n" +
+	"\x13CreateAnalysisBatch\x120.deepseek.analysis.v1.CreateAnalysisBatchRequest\x1a#.deepseek.analysis.v1.AnalysisBatch\x12l\n" +
+	"\x10GetAnalysisBatch\x12-.deepseek.analysis.v1.GetAnalysisBatchRequest\x1a).deepseek.analysis.v1.AnalysisBatchStatus\x12p\n" +
+	"\x12WatchAnalysisBatch\x12-.deepseek.analysis.v1.GetAnalysisBatchRequest\x1a).deepseek.analysis.v1.AnalysisBatchStatus0\x01\x12l\n" +
+	"\x13CancelAnalysisBatch\x120.deepseek.analysis.v1.CancelAnalysisBatchRequest\x1a#.deepseek.analysis.v1.AnalysisBatchB2Z0deepseek_golang_demo/proto/analysispb;analysispbb\x06proto3"
+
+var (
+	file_analysis_proto_rawDescOnce sync.Once
+	file_analysis_proto_rawDescData []byte
+)
+
+func file_analysis_proto_rawDescGZIP() []byte {
+	file_analysis_proto_rawDescOnce.Do(func() {
+		file_analysis_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analysis_proto_rawDesc), len(file_analysis_proto_rawDesc)))
+	})
+	return file_analysis_proto_rawDescData
+}
+
+var file_analysis_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_analysis_proto_goTypes = []any{
+	(*Record)(nil),                     // 0: deepseek.analysis.v1.Record
+	(*AutoAnalysis)(nil),               // 1: deepseek.analysis.v1.AutoAnalysis
+	(*RecordResponse)(nil),             // 2: deepseek.analysis.v1.RecordResponse
+	(*CreateRecordRequest)(nil),        // 3: deepseek.analysis.v1.CreateRecordRequest
+	(*GetRecordRequest)(nil),           // 4: deepseek.analysis.v1.GetRecordRequest
+	(*RecordFilter)(nil),               // 5: deepseek.analysis.v1.RecordFilter
+	(*ListRecordsRequest)(nil),         // 6: deepseek.analysis.v1.ListRecordsRequest
+	(*ListRecordsResponse)(nil),        // 7: deepseek.analysis.v1.ListRecordsResponse
+	(*UpdateRecordRequest)(nil),        // 8: deepseek.analysis.v1.UpdateRecordRequest
+	(*DeleteRecordRequest)(nil),        // 9: deepseek.analysis.v1.DeleteRecordRequest
+	(*DeleteRecordResponse)(nil),       // 10: deepseek.analysis.v1.DeleteRecordResponse
+	(*RestoreRecordRequest)(nil),       // 11: deepseek.analysis.v1.RestoreRecordRequest
+	(*ActionExecution)(nil),            // 12: deepseek.analysis.v1.ActionExecution
+	(*ListRecordActionsRequest)(nil),   // 13: deepseek.analysis.v1.ListRecordActionsRequest
+	(*ListRecordActionsResponse)(nil),  // 14: deepseek.analysis.v1.ListRecordActionsResponse
+	(*AnalysisResult)(nil),             // 15: deepseek.analysis.v1.AnalysisResult
+	(*AnalyzeRecordRequest)(nil),       // 16: deepseek.analysis.v1.AnalyzeRecordRequest
+	(*AnalyzeRecordsRequest)(nil),      // 17: deepseek.analysis.v1.AnalyzeRecordsRequest
+	(*Error)(nil),                      // 18: deepseek.analysis.v1.Error
+	(*AnalyzeRecordsEvent)(nil),        // 19: deepseek.analysis.v1.AnalyzeRecordsEvent
+	(*AnalysisBatch)(nil),              // 20: deepseek.analysis.v1.AnalysisBatch
+	(*AnalysisBatchItem)(nil),          // 21: deepseek.analysis.v1.AnalysisBatchItem
+	(*AnalysisBatchReport)(nil),        // 22: deepseek.analysis.v1.AnalysisBatchReport
+	(*AnalysisBatchStatus)(nil),        // 23: deepseek.analysis.v1.AnalysisBatchStatus
+	(*CreateAnalysisBatchRequest)(nil), // 24: deepseek.analysis.v1.CreateAnalysisBatchRequest
+	(*GetAnalysisBatchRequest)(nil),    // 25: deepseek.analysis.v1.GetAnalysisBatchRequest
+	(*CancelAnalysisBatchRequest)(nil), // 26: deepseek.analysis.v1.CancelAnalysisBatchRequest
+	nil,                                // 27: deepseek.analysis.v1.RecordFilter.MetadataEntry
+	nil,                                // 28: deepseek.analysis.v1.AnalysisBatchReport.SeverityCountsEntry
+	(*structpb.Struct)(nil),            // 29: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),      // 30: google.protobuf.Timestamp
+}
+var file_analysis_proto_depIdxs = []int32{
+	29, // 0: deepseek.analysis.v1.Record.metadata:type_name -> google.protobuf.Struct
+	30, // 1: deepseek.analysis.v1.Record.created_at:type_name -> google.protobuf.Timestamp
+	30, // 2: deepseek.analysis.v1.Record.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 3: deepseek.analysis.v1.Record.deleted_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: deepseek.analysis.v1.RecordResponse.record:type_name -> deepseek.analysis.v1.Record
+	1,  // 5: deepseek.analysis.v1.RecordResponse.auto_analysis:type_name -> deepseek.analysis.v1.AutoAnalysis
+	29, // 6: deepseek.analysis.v1.CreateRecordRequest.metadata:type_name -> google.protobuf.Struct
+	27, // 7: deepseek.analysis.v1.RecordFilter.metadata:type_name -> deepseek.analysis.v1.RecordFilter.MetadataEntry
+	30, // 8: deepseek.analysis.v1.RecordFilter.created_after:type_name -> google.protobuf.Timestamp
+	30, // 9: deepseek.analysis.v1.RecordFilter.created_before:type_name -> google.protobuf.Timestamp
+	30, // 10: deepseek.analysis.v1.RecordFilter.updated_after:type_name -> google.protobuf.Timestamp
+	30, // 11: deepseek.analysis.v1.RecordFilter.updated_before:type_name -> google.protobuf.Timestamp
+	5,  // 12: deepseek.analysis.v1.ListRecordsRequest.filter:type_name -> deepseek.analysis.v1.RecordFilter
+	0,  // 13: deepseek.analysis.v1.ListRecordsResponse.records:type_name -> deepseek.analysis.v1.Record
+	29, // 14: deepseek.analysis.v1.UpdateRecordRequest.metadata:type_name -> google.protobuf.Struct
+	29, // 15: deepseek.analysis.v1.ActionExecution.params:type_name -> google.protobuf.Struct
+	30, // 16: deepseek.analysis.v1.ActionExecution.created_at:type_name -> google.protobuf.Timestamp
+	12, // 17: deepseek.analysis.v1.ListRecordActionsResponse.actions:type_name -> deepseek.analysis.v1.ActionExecution
+	30, // 18: deepseek.analysis.v1.AnalysisResult.created_at:type_name -> google.protobuf.Timestamp
+	15, // 19: deepseek.analysis.v1.AnalyzeRecordsEvent.result:type_name -> deepseek.analysis.v1.AnalysisResult
+	18, // 20: deepseek.analysis.v1.AnalyzeRecordsEvent.error:type_name -> deepseek.analysis.v1.Error
+	30, // 21: deepseek.analysis.v1.AnalysisBatch.created_at:type_name -> google.protobuf.Timestamp
+	30, // 22: deepseek.analysis.v1.AnalysisBatch.started_at:type_name -> google.protobuf.Timestamp
+	30, // 23: deepseek.analysis.v1.AnalysisBatch.finished_at:type_name -> google.protobuf.Timestamp
+	30, // 24: deepseek.analysis.v1.AnalysisBatchItem.updated_at:type_name -> google.protobuf.Timestamp
+	28, // 25: deepseek.analysis.v1.AnalysisBatchReport.severity_counts:type_name -> deepseek.analysis.v1.AnalysisBatchReport.SeverityCountsEntry
+	21, // 26: deepseek.analysis.v1.AnalysisBatchReport.failures:type_name -> deepseek.analysis.v1.AnalysisBatchItem
+	20, // 27: deepseek.analysis.v1.AnalysisBatchStatus.batch:type_name -> deepseek.analysis.v1.AnalysisBatch
+	22, // 28: deepseek.analysis.v1.AnalysisBatchStatus.report:type_name -> deepseek.analysis.v1.AnalysisBatchReport
+	5,  // 29: deepseek.analysis.v1.CreateAnalysisBatchRequest.filter:type_name -> deepseek.analysis.v1.RecordFilter
+	3,  // 30: deepseek.analysis.v1.DataAnalysis.CreateRecord:input_type -> deepseek.analysis.v1.CreateRecordRequest
+	4,  // 31: deepseek.analysis.v1.DataAnalysis.GetRecord:input_type -> deepseek.analysis.v1.GetRecordRequest
+	6,  // 32: deepseek.analysis.v1.DataAnalysis.ListRecords:input_type -> deepseek.analysis.v1.ListRecordsRequest
+	8,  // 33: deepseek.analysis.v1.DataAnalysis.UpdateRecord:input_type -> deepseek.analysis.v1.UpdateRecordRequest
+	9,  // 34: deepseek.analysis.v1.DataAnalysis.DeleteRecord:input_type -> deepseek.analysis.v1.DeleteRecordRequest
+	11, // 35: deepseek.analysis.v1.DataAnalysis.RestoreRecord:input_type -> deepseek.analysis.v1.RestoreRecordRequest
+	13, // 36: deepseek.analysis.v1.DataAnalysis.ListRecordActions:input_type -> deepseek.analysis.v1.ListRecordActionsRequest
+	16, // 37: deepseek.analysis.v1.DataAnalysis.AnalyzeRecord:input_type -> deepseek.analysis.v1.AnalyzeRecordRequest
+	17, // 38: deepseek.analysis.v1.DataAnalysis.AnalyzeRecords:input_type -> deepseek.analysis.v1.AnalyzeRecordsRequest
+	24, // 39: deepseek.analysis.v1.DataAnalysis.CreateAnalysisBatch:input_type -> deepseek.analysis.v1.CreateAnalysisBatchRequest
+	25, // 40: deepseek.analysis.v1.DataAnalysis.GetAnalysisBatch:input_type -> deepseek.analysis.v1.GetAnalysisBatchRequest
+	25, // 41: deepseek.analysis.v1.DataAnalysis.WatchAnalysisBatch:input_type -> deepseek.analysis.v1.GetAnalysisBatchRequest
+	26, // 42: deepseek.analysis.v1.DataAnalysis.CancelAnalysisBatch:input_type -> deepseek.analysis.v1.CancelAnalysisBatchRequest
+	2,  // 43: deepseek.analysis.v1.DataAnalysis.CreateRecord:output_type -> deepseek.analysis.v1.RecordResponse
+	0,  // 44: deepseek.analysis.v1.DataAnalysis.GetRecord:output_type -> deepseek.analysis.v1.Record
+	7,  // 45: deepseek.analysis.v1.DataAnalysis.ListRecords:output_type -> deepseek.analysis.v1.ListRecordsResponse
+	2,  // 46: deepseek.analysis.v1.DataAnalysis.UpdateRecord:output_type -> deepseek.analysis.v1.RecordResponse
+	10, // 47: deepseek.analysis.v1.DataAnalysis.DeleteRecord:output_type -> deepseek.analysis.v1.DeleteRecordResponse
+	0,  // 48: deepseek.analysis.v1.DataAnalysis.RestoreRecord:output_type -> deepseek.analysis.v1.Record
+	14, // 49: deepseek.analysis.v1.DataAnalysis.ListRecordActions:output_type -> deepseek.analysis.v1.ListRecordActionsResponse
+	15, // 50: deepseek.analysis.v1.DataAnalysis.AnalyzeRecord:output_type -> deepseek.analysis.v1.AnalysisResult
+	19, // 51: deepseek.analysis.v1.DataAnalysis.AnalyzeRecords:output_type -> deepseek.analysis.v1.AnalyzeRecordsEvent
+	20, // 52: deepseek.analysis.v1.DataAnalysis.CreateAnalysisBatch:output_type -> deepseek.analysis.v1.AnalysisBatch
+	23, // 53: deepseek.analysis.v1.DataAnalysis.GetAnalysisBatch:output_type -> deepseek.analysis.v1.AnalysisBatchStatus
+	23, // 54: deepseek.analysis.v1.DataAnalysis.WatchAnalysisBatch:output_type -> deepseek.analysis.v1.AnalysisBatchStatus
+	20, // 55: deepseek.analysis.v1.DataAnalysis.CancelAnalysisBatch:output_type -> deepseek.analysis.v1.AnalysisBatch
+	43, // [43:56] is the sub-list for method output_type
+	30, // [30:43] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_analysis_proto_init() }
+func file_analysis_proto_init() {
+	if File_analysis_proto != nil {
+		return
+	}
+	file_analysis_proto_msgTypes[5].OneofWrappers = []any{}
+	file_analysis_proto_msgTypes[8].OneofWrappers = []any{}
+	file_analysis_proto_msgTypes[19].OneofWrappers = []any{
+		(*AnalyzeRecordsEvent_Result)(nil),
+		(*AnalyzeRecordsEvent_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analysis_proto_rawDesc), len(file_analysis_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analysis_proto_goTypes,
+		DependencyIndexes: file_analysis_proto_depIdxs,
+		MessageInfos:      file_analysis_proto_msgTypes,
+	}.Build()
+	File_analysis_proto = out.File
+	file_analysis_proto_goTypes = nil
+	file_analysis_proto_depIdxs = nil
+}
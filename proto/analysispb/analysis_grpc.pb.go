@@ -0,0 +1,597 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: analysis.proto
+
+// 数据分析 gRPC 接口，与 HTTP 接口共用同一套业务逻辑。
+// 认证方式与 HTTP 接口相同：在 metadata 中传入 authorization: Bearer <key> 或 x-api-key
+
+package analysispb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DataAnalysis_CreateRecord_FullMethodName        = "/deepseek.analysis.v1.DataAnalysis/CreateRecord"
+	DataAnalysis_GetRecord_FullMethodName           = "/deepseek.analysis.v1.DataAnalysis/GetRecord"
+	DataAnalysis_ListRecords_FullMethodName         = "/deepseek.analysis.v1.DataAnalysis/ListRecords"
+	DataAnalysis_UpdateRecord_FullMethodName        = "/deepseek.analysis.v1.DataAnalysis/UpdateRecord"
+	DataAnalysis_DeleteRecord_FullMethodName        = "/deepseek.analysis.v1.DataAnalysis/DeleteRecord"
+	DataAnalysis_RestoreRecord_FullMethodName       = "/deepseek.analysis.v1.DataAnalysis/RestoreRecord"
+	DataAnalysis_ListRecordActions_FullMethodName   = "/deepseek.analysis.v1.DataAnalysis/ListRecordActions"
+	DataAnalysis_AnalyzeRecord_FullMethodName       = "/deepseek.analysis.v1.DataAnalysis/AnalyzeRecord"
+	DataAnalysis_AnalyzeRecords_FullMethodName      = "/deepseek.analysis.v1.DataAnalysis/AnalyzeRecords"
+	DataAnalysis_CreateAnalysisBatch_FullMethodName = "/deepseek.analysis.v1.DataAnalysis/CreateAnalysisBatch"
+	DataAnalysis_GetAnalysisBatch_FullMethodName    = "/deepseek.analysis.v1.DataAnalysis/GetAnalysisBatch"
+	DataAnalysis_WatchAnalysisBatch_FullMethodName  = "/deepseek.analysis.v1.DataAnalysis/WatchAnalysisBatch"
+	DataAnalysis_CancelAnalysisBatch_FullMethodName = "/deepseek.analysis.v1.DataAnalysis/CancelAnalysisBatch"
+)
+
+// DataAnalysisClient is the client API for DataAnalysis service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DataAnalysisClient interface {
+	// 记录
+	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*RecordResponse, error)
+	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*Record, error)
+	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error)
+	UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*RecordResponse, error)
+	DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*DeleteRecordResponse, error)
+	RestoreRecord(ctx context.Context, in *RestoreRecordRequest, opts ...grpc.CallOption) (*Record, error)
+	ListRecordActions(ctx context.Context, in *ListRecordActionsRequest, opts ...grpc.CallOption) (*ListRecordActionsResponse, error)
+	// 分析
+	AnalyzeRecord(ctx context.Context, in *AnalyzeRecordRequest, opts ...grpc.CallOption) (*AnalysisResult, error)
+	// 依次分析多条记录，每条记录分析完成后立即返回结果
+	AnalyzeRecords(ctx context.Context, in *AnalyzeRecordsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalyzeRecordsEvent], error)
+	// 批量分析任务
+	CreateAnalysisBatch(ctx context.Context, in *CreateAnalysisBatchRequest, opts ...grpc.CallOption) (*AnalysisBatch, error)
+	GetAnalysisBatch(ctx context.Context, in *GetAnalysisBatchRequest, opts ...grpc.CallOption) (*AnalysisBatchStatus, error)
+	// 任务进度变化时返回最新状态，任务结束后附带汇总报告并结束
+	WatchAnalysisBatch(ctx context.Context, in *GetAnalysisBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisBatchStatus], error)
+	CancelAnalysisBatch(ctx context.Context, in *CancelAnalysisBatchRequest, opts ...grpc.CallOption) (*AnalysisBatch, error)
+}
+
+type dataAnalysisClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDataAnalysisClient(cc grpc.ClientConnInterface) DataAnalysisClient {
+	return &dataAnalysisClient{cc}
+}
+
+func (c *dataAnalysisClient) CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*RecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordResponse)
+	err := c.cc.Invoke(ctx, DataAnalysis_CreateRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*Record, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Record)
+	err := c.cc.Invoke(ctx, DataAnalysis_GetRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecordsResponse)
+	err := c.cc.Invoke(ctx, DataAnalysis_ListRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*RecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordResponse)
+	err := c.cc.Invoke(ctx, DataAnalysis_UpdateRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*DeleteRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRecordResponse)
+	err := c.cc.Invoke(ctx, DataAnalysis_DeleteRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) RestoreRecord(ctx context.Context, in *RestoreRecordRequest, opts ...grpc.CallOption) (*Record, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Record)
+	err := c.cc.Invoke(ctx, DataAnalysis_RestoreRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) ListRecordActions(ctx context.Context, in *ListRecordActionsRequest, opts ...grpc.CallOption) (*ListRecordActionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecordActionsResponse)
+	err := c.cc.Invoke(ctx, DataAnalysis_ListRecordActions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) AnalyzeRecord(ctx context.Context, in *AnalyzeRecordRequest, opts ...grpc.CallOption) (*AnalysisResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalysisResult)
+	err := c.cc.Invoke(ctx, DataAnalysis_AnalyzeRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) AnalyzeRecords(ctx context.Context, in *AnalyzeRecordsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalyzeRecordsEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataAnalysis_ServiceDesc.Streams[0], DataAnalysis_AnalyzeRecords_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AnalyzeRecordsRequest, AnalyzeRecordsEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAnalysis_AnalyzeRecordsClient = grpc.ServerStreamingClient[AnalyzeRecordsEvent]
+
+func (c *dataAnalysisClient) CreateAnalysisBatch(ctx context.Context, in *CreateAnalysisBatchRequest, opts ...grpc.CallOption) (*AnalysisBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalysisBatch)
+	err := c.cc.Invoke(ctx, DataAnalysis_CreateAnalysisBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) GetAnalysisBatch(ctx context.Context, in *GetAnalysisBatchRequest, opts ...grpc.CallOption) (*AnalysisBatchStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalysisBatchStatus)
+	err := c.cc.Invoke(ctx, DataAnalysis_GetAnalysisBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAnalysisClient) WatchAnalysisBatch(ctx context.Context, in *GetAnalysisBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisBatchStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataAnalysis_ServiceDesc.Streams[1], DataAnalysis_WatchAnalysisBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetAnalysisBatchRequest, AnalysisBatchStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAnalysis_WatchAnalysisBatchClient = grpc.ServerStreamingClient[AnalysisBatchStatus]
+
+func (c *dataAnalysisClient) CancelAnalysisBatch(ctx context.Context, in *CancelAnalysisBatchRequest, opts ...grpc.CallOption) (*AnalysisBatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalysisBatch)
+	err := c.cc.Invoke(ctx, DataAnalysis_CancelAnalysisBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DataAnalysisServer is the server API for DataAnalysis service.
+// All implementations must embed UnimplementedDataAnalysisServer
+// for forward compatibility.
+type DataAnalysisServer interface {
+	// 记录
+	CreateRecord(context.Context, *CreateRecordRequest) (*RecordResponse, error)
+	GetRecord(context.Context, *GetRecordRequest) (*Record, error)
+	ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error)
+	UpdateRecord(context.Context, *UpdateRecordRequest) (*RecordResponse, error)
+	DeleteRecord(context.Context, *DeleteRecordRequest) (*DeleteRecordResponse, error)
+	RestoreRecord(context.Context, *RestoreRecordRequest) (*Record, error)
+	ListRecordActions(context.Context, *ListRecordActionsRequest) (*ListRecordActionsResponse, error)
+	// 分析
+	AnalyzeRecord(context.Context, *AnalyzeRecordRequest) (*AnalysisResult, error)
+	// 依次分析多条记录，每条记录分析完成后立即返回结果
+	AnalyzeRecords(*AnalyzeRecordsRequest, grpc.ServerStreamingServer[AnalyzeRecordsEvent]) error
+	// 批量分析任务
+	CreateAnalysisBatch(context.Context, *CreateAnalysisBatchRequest) (*AnalysisBatch, error)
+	GetAnalysisBatch(context.Context, *GetAnalysisBatchRequest) (*AnalysisBatchStatus, error)
+	// 任务进度变化时返回最新状态，任务结束后附带汇总报告并结束
+	WatchAnalysisBatch(*GetAnalysisBatchRequest, grpc.ServerStreamingServer[AnalysisBatchStatus]) error
+	CancelAnalysisBatch(context.Context, *CancelAnalysisBatchRequest) (*AnalysisBatch, error)
+	mustEmbedUnimplementedDataAnalysisServer()
+}
+
+// UnimplementedDataAnalysisServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDataAnalysisServer struct{}
+
+func (UnimplementedDataAnalysisServer) CreateRecord(context.Context, *CreateRecordRequest) (*RecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
+}
+func (UnimplementedDataAnalysisServer) GetRecord(context.Context, *GetRecordRequest) (*Record, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
+}
+func (UnimplementedDataAnalysisServer) ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecords not implemented")
+}
+func (UnimplementedDataAnalysisServer) UpdateRecord(context.Context, *UpdateRecordRequest) (*RecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecord not implemented")
+}
+func (UnimplementedDataAnalysisServer) DeleteRecord(context.Context, *DeleteRecordRequest) (*DeleteRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecord not implemented")
+}
+func (UnimplementedDataAnalysisServer) RestoreRecord(context.Context, *RestoreRecordRequest) (*Record, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRecord not implemented")
+}
+func (UnimplementedDataAnalysisServer) ListRecordActions(context.Context, *ListRecordActionsRequest) (*ListRecordActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordActions not implemented")
+}
+func (UnimplementedDataAnalysisServer) AnalyzeRecord(context.Context, *AnalyzeRecordRequest) (*AnalysisResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeRecord not implemented")
+}
+func (UnimplementedDataAnalysisServer) AnalyzeRecords(*AnalyzeRecordsRequest, grpc.ServerStreamingServer[AnalyzeRecordsEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method AnalyzeRecords not implemented")
+}
+func (UnimplementedDataAnalysisServer) CreateAnalysisBatch(context.Context, *CreateAnalysisBatchRequest) (*AnalysisBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAnalysisBatch not implemented")
+}
+func (UnimplementedDataAnalysisServer) GetAnalysisBatch(context.Context, *GetAnalysisBatchRequest) (*AnalysisBatchStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnalysisBatch not implemented")
+}
+func (UnimplementedDataAnalysisServer) WatchAnalysisBatch(*GetAnalysisBatchRequest, grpc.ServerStreamingServer[AnalysisBatchStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAnalysisBatch not implemented")
+}
+func (UnimplementedDataAnalysisServer) CancelAnalysisBatch(context.Context, *CancelAnalysisBatchRequest) (*AnalysisBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAnalysisBatch not implemented")
+}
+func (UnimplementedDataAnalysisServer) mustEmbedUnimplementedDataAnalysisServer() {}
+func (UnimplementedDataAnalysisServer) testEmbeddedByValue()                      {}
+
+// UnsafeDataAnalysisServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DataAnalysisServer will
+// result in compilation errors.
+type UnsafeDataAnalysisServer interface {
+	mustEmbedUnimplementedDataAnalysisServer()
+}
+
+func RegisterDataAnalysisServer(s grpc.ServiceRegistrar, srv DataAnalysisServer) {
+	// If the following call pancis, it indicates UnimplementedDataAnalysisServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DataAnalysis_ServiceDesc, srv)
+}
+
+func _DataAnalysis_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).CreateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_CreateRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).CreateRecord(ctx, req.(*CreateRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).GetRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_GetRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).GetRecord(ctx, req.(*GetRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_ListRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).ListRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_ListRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).ListRecords(ctx, req.(*ListRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_UpdateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).UpdateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_UpdateRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).UpdateRecord(ctx, req.(*UpdateRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_DeleteRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).DeleteRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_DeleteRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).DeleteRecord(ctx, req.(*DeleteRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_RestoreRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).RestoreRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_RestoreRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).RestoreRecord(ctx, req.(*RestoreRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_ListRecordActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).ListRecordActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_ListRecordActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).ListRecordActions(ctx, req.(*ListRecordActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_AnalyzeRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).AnalyzeRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_AnalyzeRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).AnalyzeRecord(ctx, req.(*AnalyzeRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_AnalyzeRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AnalyzeRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataAnalysisServer).AnalyzeRecords(m, &grpc.GenericServerStream[AnalyzeRecordsRequest, AnalyzeRecordsEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAnalysis_AnalyzeRecordsServer = grpc.ServerStreamingServer[AnalyzeRecordsEvent]
+
+func _DataAnalysis_CreateAnalysisBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAnalysisBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).CreateAnalysisBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_CreateAnalysisBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).CreateAnalysisBatch(ctx, req.(*CreateAnalysisBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_GetAnalysisBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnalysisBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).GetAnalysisBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_GetAnalysisBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).GetAnalysisBatch(ctx, req.(*GetAnalysisBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAnalysis_WatchAnalysisBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAnalysisBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataAnalysisServer).WatchAnalysisBatch(m, &grpc.GenericServerStream[GetAnalysisBatchRequest, AnalysisBatchStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAnalysis_WatchAnalysisBatchServer = grpc.ServerStreamingServer[AnalysisBatchStatus]
+
+func _DataAnalysis_CancelAnalysisBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAnalysisBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAnalysisServer).CancelAnalysisBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAnalysis_CancelAnalysisBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAnalysisServer).CancelAnalysisBatch(ctx, req.(*CancelAnalysisBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DataAnalysis_ServiceDesc is the grpc.ServiceDesc for DataAnalysis service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DataAnalysis_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "deepseek.analysis.v1.DataAnalysis",
+	HandlerType: (*DataAnalysisServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRecord",
+			Handler:    _DataAnalysis_CreateRecord_Handler,
+		},
+		{
+			MethodName: "GetRecord",
+			Handler:    _DataAnalysis_GetRecord_Handler,
+		},
+		{
+			MethodName: "ListRecords",
+			Handler:    _DataAnalysis_ListRecords_Handler,
+		},
+		{
+			MethodName: "UpdateRecord",
+			Handler:    _DataAnalysis_UpdateRecord_Handler,
+		},
+		{
+			MethodName: "DeleteRecord",
+			Handler:    _DataAnalysis_DeleteRecord_Handler,
+		},
+		{
+			MethodName: "RestoreRecord",
+			Handler:    _DataAnalysis_RestoreRecord_Handler,
+		},
+		{
+			MethodName: "ListRecordActions",
+			Handler:    _DataAnalysis_ListRecordActions_Handler,
+		},
+		{
+			MethodName: "AnalyzeRecord",
+			Handler:    _DataAnalysis_AnalyzeRecord_Handler,
+		},
+		{
+			MethodName: "CreateAnalysisBatch",
+			Handler:    _DataAnalysis_CreateAnalysisBatch_Handler,
+		},
+		{
+			MethodName: "GetAnalysisBatch",
+			Handler:    _DataAnalysis_GetAnalysisBatch_Handler,
+		},
+		{
+			MethodName: "CancelAnalysisBatch",
+			Handler:    _DataAnalysis_CancelAnalysisBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AnalyzeRecords",
+			Handler:       _DataAnalysis_AnalyzeRecords_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchAnalysisBatch",
+			Handler:       _DataAnalysis_WatchAnalysisBatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "analysis.proto",
+}
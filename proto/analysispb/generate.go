@@ -0,0 +1,4 @@
+// Package analysispb 是 analysis.proto 生成的 gRPC 接口代码，修改 analysis.proto 后需重新生成
+package analysispb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative analysis.proto
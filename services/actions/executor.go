@@ -9,22 +9,44 @@ import (
 	"deepseek_golang_demo/services/notification"
 )
 
-// ExecuteAction 执行建议操作
-func ExecuteAction(action models.Action, db *sql.DB) error {
+// 建议操作的类型
+const (
+	TypeDatabase     = "database"
+	TypeNotification = "notification"
+	TypeTag          = "tag"
+)
+
+// Executor 在数据库中执行建议操作，操作的租户为触发操作的记录所属租户
+type Executor struct {
+	db *sql.DB
+}
+
+func NewExecutor(db *sql.DB) *Executor {
+	return &Executor{db: db}
+}
+
+// Execute 执行建议操作，record 和 result 为触发该操作的记录及其分析结果
+func (e *Executor) Execute(action models.Action, record *models.DataRecord, result *models.AnalysisResult) error {
+	return ExecuteAction(action, e.db, record.TenantID, record, result)
+}
+
+// ExecuteAction 在租户 tenantID 内执行建议操作，record 和 result 为触发该操作的记录及其分析结果。
+// 操作参数中的 record_id 由模型给出，只能指向同一租户的记录
+func ExecuteAction(action models.Action, db *sql.DB, tenantID int64, record *models.DataRecord, result *models.AnalysisResult) error {
 	switch action.Type {
-	case "database":
-		return executeDatabaseAction(action, db)
-	case "notification":
-		return executeNotificationAction(action, db)
-	case "tag":
-		return executeTaggingAction(action, db)
+	case TypeDatabase:
+		return executeDatabaseAction(action, db, tenantID)
+	case TypeNotification:
+		return executeNotificationAction(action, db, tenantID, record, result)
+	case TypeTag:
+		return executeTaggingAction(action, db, tenantID)
 	default:
 		return fmt.Errorf("unknown action type: %s", action.Type)
 	}
 }
 
 // executeDatabaseAction 执行数据库操作
-func executeDatabaseAction(action models.Action, db *sql.DB) error {
+func executeDatabaseAction(action models.Action, db *sql.DB, tenantID int64) error {
 	switch action.Target {
 	case "update_status":
 		status, ok := action.Params["status"].(string)
@@ -35,7 +57,7 @@ func executeDatabaseAction(action models.Action, db *sql.DB) error {
 		if !ok {
 			return fmt.Errorf("invalid record_id parameter")
 		}
-		return models.UpdateStatus(db, fmt.Sprintf("%d", int64(id)), status)
+		return models.UpdateStatus(db, tenantID, fmt.Sprintf("%d", int64(id)), status)
 
 	case "add_tag":
 		tag, ok := action.Params["tag"].(string)
@@ -46,57 +68,66 @@ func executeDatabaseAction(action models.Action, db *sql.DB) error {
 		if !ok {
 			return fmt.Errorf("invalid record_id parameter")
 		}
-		return models.AddTag(db, fmt.Sprintf("%d", int64(id)), tag)
+		_, err := models.AddTag(db, tenantID, int64(id), tag, models.TagSourceLLM)
+		return err
 
 	default:
 		return fmt.Errorf("unknown database action target: %s", action.Target)
 	}
 }
 
-// executeNotificationAction 执行通知操作
-func executeNotificationAction(action models.Action, db *sql.DB) error {
-	message, ok := action.Params["message"].(string)
-	if !ok {
-		return fmt.Errorf("invalid message parameter")
-	}
-
-	channel, ok := action.Params["channel"].(string)
-	if !ok {
-		return fmt.Errorf("invalid channel parameter")
+// executeNotificationAction 执行通知操作，渠道和接收人由通知路由规则决定
+func executeNotificationAction(action models.Action, db *sql.DB, tenantID int64, record *models.DataRecord, result *models.AnalysisResult) error {
+	if record == nil {
+		recordID, ok := action.Params["record_id"].(float64)
+		if !ok {
+			return fmt.Errorf("invalid record_id parameter")
+		}
+		var err error
+		record, err = models.GetDataRecord(db, tenantID, int64(recordID))
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			return fmt.Errorf("record not found: %d", int64(recordID))
+		}
 	}
 
-	recordID, ok := action.Params["record_id"].(float64)
-	if !ok {
-		return fmt.Errorf("invalid record_id parameter")
+	notice, err := NotificationNotice(action, record, result)
+	if err != nil {
+		return err
 	}
-
-	// Create notification record
-	if err := models.CreateNotification(db, int64(recordID), channel, message); err != nil {
-		log.Printf("Failed to create notification: %v", err)
-		return fmt.Errorf("failed to create notification: %v", err)
+	if err := notification.Dispatch(db, notice); err != nil {
+		log.Printf("Failed to dispatch notification: %v", err)
+		return err
 	}
 
-	// Send notification
-	if err := notification.Send(channel, message, action.Params); err != nil {
-		log.Printf("Failed to send notification: %v", err)
-		// Update notification status to failed
-		if updateErr := models.UpdateNotificationStatus(db, int64(recordID), "failed"); updateErr != nil {
-			log.Printf("Failed to update notification status: %v", updateErr)
-		}
-		return fmt.Errorf("failed to send notification: %v", err)
-	}
+	return nil
+}
 
-	// Update notification status to sent
-	if err := models.UpdateNotificationStatus(db, int64(recordID), "sent"); err != nil {
-		log.Printf("Failed to update notification status: %v", err)
-		return fmt.Errorf("failed to update notification status: %v", err)
+// NotificationNotice 根据通知操作的参数生成通知，record 和 result 为触发该操作的记录及其分析结果
+func NotificationNotice(action models.Action, record *models.DataRecord, result *models.AnalysisResult) (*notification.Notice, error) {
+	message, ok := action.Params["message"].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid message parameter")
 	}
 
-	return nil
+	// 渠道仅在没有匹配的路由规则时使用
+	channel, _ := action.Params["channel"].(string)
+
+	return &notification.Notice{
+		TenantID: record.TenantID,
+		Event:    notification.EventAnalysisAction,
+		Channel:  channel,
+		Message:  message,
+		Params:   action.Params,
+		Record:   record,
+		Analysis: result,
+	}, nil
 }
 
 // executeTaggingAction 执行标记操作
-func executeTaggingAction(action models.Action, db *sql.DB) error {
+func executeTaggingAction(action models.Action, db *sql.DB, tenantID int64) error {
 	tag, ok := action.Params["tag"].(string)
 	if !ok {
 		return fmt.Errorf("invalid tag parameter")
@@ -107,5 +138,6 @@ func executeTaggingAction(action models.Action, db *sql.DB) error {
 		return fmt.Errorf("invalid record_id parameter")
 	}
 
-	return models.AddTag(db, fmt.Sprintf("%d", int64(id)), tag)
+	_, err := models.AddTag(db, tenantID, int64(id), tag, models.TagSourceLLM)
+	return err
 }
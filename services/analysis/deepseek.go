@@ -0,0 +1,33 @@
+package analysis
+
+import (
+	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/deepseek"
+)
+
+// DeepSeekAnalyzer 使用 DeepSeek API 分析记录
+type DeepSeekAnalyzer struct {
+	client *deepseek.Client
+}
+
+// NewDeepSeekAnalyzer client 为租户未配置自己的 API Key 时使用的客户端
+func NewDeepSeekAnalyzer(client *deepseek.Client) *DeepSeekAnalyzer {
+	return &DeepSeekAnalyzer{client: client}
+}
+
+func (a *DeepSeekAnalyzer) Analyze(tenant *models.Tenant, prompt string, record *models.DataRecord) (*deepseek.AnalysisResponse, error) {
+	return a.tenantClient(tenant).AnalyzeData(prompt, record)
+}
+
+// tenantClient 返回租户使用的 DeepSeek 客户端：租户配置了 API Key 时使用自己的 Key，
+// 配置了 system 模板时覆盖默认的 system 提示词
+func (a *DeepSeekAnalyzer) tenantClient(tenant *models.Tenant) *deepseek.Client {
+	client := a.client
+	if tenant.DeepSeekAPIKey != "" {
+		client = deepseek.NewClient(tenant.DeepSeekAPIKey)
+	}
+	if template := tenant.PromptTemplates["system"]; template != "" {
+		client = client.WithSystemTemplate(template)
+	}
+	return client
+}
@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	"database/sql"
+
+	"deepseek_golang_demo/models"
+)
+
+// sqlRepository 基于 models 包的数据库实现
+type sqlRepository struct {
+	db *sql.DB
+}
+
+// NewRepository 返回使用数据库 db 的 RecordRepository
+func NewRepository(db *sql.DB) RecordRepository {
+	return &sqlRepository{db: db}
+}
+
+func (r *sqlRepository) GetRecord(tenantID int64, id int64) (*models.DataRecord, error) {
+	return models.GetDataRecord(r.db, tenantID, id)
+}
+
+func (r *sqlRepository) GetLatestRevision(tenantID int64, recordID int64) (*models.RecordRevision, error) {
+	return models.GetLatestRecordRevision(r.db, tenantID, recordID)
+}
+
+func (r *sqlRepository) GetTenant(id int64) (*models.Tenant, error) {
+	return models.GetTenant(r.db, id)
+}
+
+func (r *sqlRepository) CheckBudget(tenant *models.Tenant) error {
+	return models.CheckTenantBudget(r.db, tenant)
+}
+
+func (r *sqlRepository) AddUsage(tenantID int64, tokens int64) error {
+	return models.AddTenantUsage(r.db, tenantID, tokens)
+}
+
+func (r *sqlRepository) SaveAnalysisResult(result *models.AnalysisResult) error {
+	return models.SaveAnalysisResult(r.db, result)
+}
+
+func (r *sqlRepository) SaveActionExecution(execution *models.ActionExecution) error {
+	return models.CreateActionExecution(r.db, execution)
+}
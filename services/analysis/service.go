@@ -0,0 +1,195 @@
+// Package analysis 实现记录分析的业务流程：加载记录、构建提示词、调用大模型、保存分析结果并执行建议的操作。
+// 依赖通过接口注入，HTTP、gRPC、批量任务和命令行共用同一个 Service
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/actions"
+	"deepseek_golang_demo/services/deepseek"
+	"deepseek_golang_demo/services/notification"
+)
+
+var (
+	// ErrRecordNotFound 记录不存在或不属于该租户
+	ErrRecordNotFound = errors.New("record not found")
+	// ErrRecordDeleted 记录已被软删除，不能分析
+	ErrRecordDeleted = errors.New("record has been deleted")
+	// ErrBudgetExceeded 租户本月的 DeepSeek token 预算已用尽
+	ErrBudgetExceeded = models.ErrTenantBudgetExceeded
+)
+
+// RecordRepository 分析过程中读写记录、租户用量、分析结果和操作执行记录的存储
+type RecordRepository interface {
+	GetRecord(tenantID int64, id int64) (*models.DataRecord, error)
+	GetLatestRevision(tenantID int64, recordID int64) (*models.RecordRevision, error)
+	GetTenant(id int64) (*models.Tenant, error)
+	// CheckBudget 预算用尽时返回 ErrBudgetExceeded
+	CheckBudget(tenant *models.Tenant) error
+	AddUsage(tenantID int64, tokens int64) error
+	SaveAnalysisResult(result *models.AnalysisResult) error
+	SaveActionExecution(execution *models.ActionExecution) error
+}
+
+// Analyzer 调用大模型分析记录，tenant 决定使用的 API Key 和 system 提示词
+type Analyzer interface {
+	Analyze(tenant *models.Tenant, prompt string, record *models.DataRecord) (*deepseek.AnalysisResponse, error)
+}
+
+// ActionExecutor 执行分析建议的数据库和标记操作
+type ActionExecutor interface {
+	Execute(action models.Action, record *models.DataRecord, result *models.AnalysisResult) error
+}
+
+// Notifier 发送分析建议的通知
+type Notifier interface {
+	Notify(notice *notification.Notice) error
+}
+
+// Service 记录分析服务
+type Service struct {
+	repo     RecordRepository
+	analyzer Analyzer
+	executor ActionExecutor
+	notifier Notifier
+}
+
+func NewService(repo RecordRepository, analyzer Analyzer, executor ActionExecutor, notifier Notifier) *Service {
+	return &Service{repo: repo, analyzer: analyzer, executor: executor, notifier: notifier}
+}
+
+// AnalyzeByID 以 principal 的身份分析租户的记录，记录不存在时返回 ErrRecordNotFound，
+// 已删除时返回 ErrRecordDeleted
+func (s *Service) AnalyzeByID(tenantID int64, id int64, principal string) (*models.AnalysisResult, error) {
+	record, err := s.repo.GetRecord(tenantID, id)
+	if err != nil {
+		log.Printf("获取记录失败 (ID: %d): %v", id, err)
+		return nil, fmt.Errorf("error getting record: %v", err)
+	}
+	if record == nil {
+		log.Printf("记录未找到 (ID: %d)", id)
+		return nil, ErrRecordNotFound
+	}
+	if record.DeletedAt != nil {
+		log.Printf("记录已删除，拒绝分析 (ID: %d)", id)
+		return nil, ErrRecordDeleted
+	}
+	return s.Analyze(record, principal)
+}
+
+// Analyze 以 principal 的身份调用大模型分析记录的最新内容，保存分析结果并执行建议的操作。
+// 使用记录所属租户的 API Key、提示词模板和预算，预算用尽时返回 ErrBudgetExceeded。
+// 单个操作执行失败不影响分析结果，失败原因记录在操作执行记录中
+func (s *Service) Analyze(record *models.DataRecord, principal string) (*models.AnalysisResult, error) {
+	id := record.ID
+	tenant, err := s.repo.GetTenant(record.TenantID)
+	if err != nil {
+		log.Printf("获取租户失败 (ID: %d): %v", record.TenantID, err)
+		return nil, fmt.Errorf("error getting tenant: %v", err)
+	}
+	if tenant == nil {
+		return nil, fmt.Errorf("tenant %d not found", record.TenantID)
+	}
+	if err := s.repo.CheckBudget(tenant); err != nil {
+		if err != ErrBudgetExceeded {
+			log.Printf("检查租户预算失败 (ID: %d): %v", tenant.ID, err)
+		}
+		return nil, err
+	}
+
+	revision, err := s.repo.GetLatestRevision(record.TenantID, id)
+	if err != nil {
+		log.Printf("获取记录版本失败 (ID: %d): %v", id, err)
+		return nil, fmt.Errorf("error getting record revision: %v", err)
+	}
+
+	// 调用大模型进行分析
+	response, err := s.analyzer.Analyze(tenant, BuildPrompt(tenant, record), record)
+	if err != nil {
+		log.Printf("数据分析失败 (ID: %d): %v", id, err)
+		return nil, fmt.Errorf("error analyzing record: %v", err)
+	}
+	if err := s.repo.AddUsage(tenant.ID, response.TotalTokens); err != nil {
+		log.Printf("记录租户用量失败 (ID: %d): %v", tenant.ID, err)
+	}
+
+	// 保存分析结果
+	result := &models.AnalysisResult{
+		TenantID:    record.TenantID,
+		RecordID:    id,
+		Analysis:    response.Analysis,
+		Suggestions: response.Suggestions,
+		Confidence:  response.Confidence,
+		Severity:    response.Severity,
+		CreatedBy:   principal,
+	}
+	if revision != nil {
+		result.RevisionID = revision.ID
+		result.Revision = revision.Revision
+	}
+
+	if err := s.repo.SaveAnalysisResult(result); err != nil {
+		log.Printf("保存分析结果失败 (ID: %d): %v", id, err)
+		return nil, fmt.Errorf("error saving analysis result: %v", err)
+	}
+
+	s.executeActions(record, result, response.Actions, principal)
+	return result, nil
+}
+
+// executeActions 执行建议的操作并记录执行结果，通知操作由 Notifier 发送，其他操作由 ActionExecutor 执行
+func (s *Service) executeActions(record *models.DataRecord, result *models.AnalysisResult, suggested []models.Action, principal string) {
+	for i, action := range suggested {
+		execution := &models.ActionExecution{
+			RecordID:   record.ID,
+			AnalysisID: result.ID,
+			Type:       action.Type,
+			Target:     action.Target,
+			Params:     action.Params,
+			Status:     models.ActionSucceeded,
+			ExecutedBy: principal,
+		}
+
+		var err error
+		if action.Type == actions.TypeNotification {
+			var notice *notification.Notice
+			if notice, err = actions.NotificationNotice(action, record, result); err == nil {
+				err = s.notifier.Notify(notice)
+			}
+		} else {
+			err = s.executor.Execute(action, record, result)
+		}
+		if err != nil {
+			log.Printf("执行操作失败 (ID: %d, 操作索引: %d, 类型: %s): %v", record.ID, i, action.Type, err)
+			execution.Status = models.ActionFailed
+			execution.Error = err.Error()
+		}
+
+		if err := s.repo.SaveActionExecution(execution); err != nil {
+			log.Printf("保存操作执行记录失败 (ID: %d, 操作索引: %d): %v", record.ID, i, err)
+		}
+	}
+}
+
+// BuildPrompt 构建分析提示词。租户为该数据类型配置了模板时使用模板，
+// 模板中的 %TYPE%、%CONTENT%、%METRICS% 分别替换为数据类型、内容和关注指标
+func BuildPrompt(tenant *models.Tenant, record *models.DataRecord) string {
+	metrics := models.MetricNames(record)
+	if template := tenant.PromptTemplates[record.Type]; template != "" {
+		return strings.NewReplacer(
+			"%TYPE%", record.Type,
+			"%CONTENT%", record.Content,
+			"%METRICS%", strings.Join(metrics, "、"),
+		).Replace(template)
+	}
+
+	prompt := fmt.Sprintf("请分析以下%s类型的数据：\n%s", record.Type, record.Content)
+	if len(metrics) > 0 {
+		prompt += "\n关注指标：" + strings.Join(metrics, "、")
+	}
+	return prompt
+}
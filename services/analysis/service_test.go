@@ -0,0 +1,256 @@
+package analysis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"deepseek_golang_demo/models"
+	"deepseek_golang_demo/services/actions"
+	"deepseek_golang_demo/services/deepseek"
+	"deepseek_golang_demo/services/notification"
+)
+
+// fakeRepository 内存中的 RecordRepository
+type fakeRepository struct {
+	records    map[int64]*models.DataRecord
+	tenants    map[int64]*models.Tenant
+	revisions  map[int64]*models.RecordRevision
+	budgetErr  error
+	usage      map[int64]int64
+	results    []*models.AnalysisResult
+	executions []*models.ActionExecution
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		records:   map[int64]*models.DataRecord{},
+		tenants:   map[int64]*models.Tenant{1: {ID: 1, Slug: "default"}},
+		revisions: map[int64]*models.RecordRevision{},
+		usage:     map[int64]int64{},
+	}
+}
+
+func (r *fakeRepository) GetRecord(tenantID int64, id int64) (*models.DataRecord, error) {
+	record, ok := r.records[id]
+	if !ok || record.TenantID != tenantID {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (r *fakeRepository) GetLatestRevision(tenantID int64, recordID int64) (*models.RecordRevision, error) {
+	return r.revisions[recordID], nil
+}
+
+func (r *fakeRepository) GetTenant(id int64) (*models.Tenant, error) {
+	return r.tenants[id], nil
+}
+
+func (r *fakeRepository) CheckBudget(tenant *models.Tenant) error {
+	return r.budgetErr
+}
+
+func (r *fakeRepository) AddUsage(tenantID int64, tokens int64) error {
+	r.usage[tenantID] += tokens
+	return nil
+}
+
+func (r *fakeRepository) SaveAnalysisResult(result *models.AnalysisResult) error {
+	result.ID = int64(len(r.results) + 1)
+	r.results = append(r.results, result)
+	return nil
+}
+
+func (r *fakeRepository) SaveActionExecution(execution *models.ActionExecution) error {
+	execution.ID = int64(len(r.executions) + 1)
+	r.executions = append(r.executions, execution)
+	return nil
+}
+
+// fakeAnalyzer 返回固定的分析结果
+type fakeAnalyzer struct {
+	response *deepseek.AnalysisResponse
+	calls    int
+}
+
+func (a *fakeAnalyzer) Analyze(tenant *models.Tenant, prompt string, record *models.DataRecord) (*deepseek.AnalysisResponse, error) {
+	a.calls++
+	return a.response, nil
+}
+
+// fakeExecutor 记录执行的操作，err 不为空时所有操作都执行失败
+type fakeExecutor struct {
+	err      error
+	executed []models.Action
+}
+
+func (e *fakeExecutor) Execute(action models.Action, record *models.DataRecord, result *models.AnalysisResult) error {
+	e.executed = append(e.executed, action)
+	return e.err
+}
+
+// fakeNotifier 记录发送的通知
+type fakeNotifier struct {
+	notices []*notification.Notice
+}
+
+func (n *fakeNotifier) Notify(notice *notification.Notice) error {
+	n.notices = append(n.notices, notice)
+	return nil
+}
+
+type serviceFixture struct {
+	repo     *fakeRepository
+	analyzer *fakeAnalyzer
+	executor *fakeExecutor
+	notifier *fakeNotifier
+	service  *Service
+}
+
+func newServiceFixture(actions ...models.Action) *serviceFixture {
+	f := &serviceFixture{
+		repo: newFakeRepository(),
+		analyzer: &fakeAnalyzer{response: &deepseek.AnalysisResponse{
+			Analysis:    "CPU 使用率持续偏高",
+			Confidence:  0.9,
+			Severity:    3,
+			Actions:     actions,
+			TotalTokens: 120,
+		}},
+		executor: &fakeExecutor{},
+		notifier: &fakeNotifier{},
+	}
+	f.repo.records[1] = &models.DataRecord{ID: 1, TenantID: 1, Type: "metrics", Content: "cpu=95"}
+	f.repo.revisions[1] = &models.RecordRevision{ID: 7, RecordID: 1, Revision: 2}
+	f.service = NewService(f.repo, f.analyzer, f.executor, f.notifier)
+	return f
+}
+
+func TestAnalyzeByIDSavesResult(t *testing.T) {
+	f := newServiceFixture()
+
+	result, err := f.service.AnalyzeByID(1, 1, "alice")
+	if err != nil {
+		t.Fatalf("AnalyzeByID: %v", err)
+	}
+	if len(f.repo.results) != 1 || f.repo.results[0] != result {
+		t.Fatalf("saved results = %v, want the returned result", f.repo.results)
+	}
+	if result.RevisionID != 7 || result.Revision != 2 {
+		t.Errorf("revision = (%d, %d), want (7, 2)", result.RevisionID, result.Revision)
+	}
+	if result.CreatedBy != "alice" || result.Severity != 3 {
+		t.Errorf("result = %+v", result)
+	}
+	if f.repo.usage[1] != 120 {
+		t.Errorf("usage = %d, want 120", f.repo.usage[1])
+	}
+}
+
+func TestAnalyzeByIDRecordNotFound(t *testing.T) {
+	f := newServiceFixture()
+
+	for _, tc := range []struct {
+		name     string
+		tenantID int64
+		id       int64
+	}{
+		{"missing record", 1, 2},
+		{"other tenant", 2, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := f.service.AnalyzeByID(tc.tenantID, tc.id, "alice"); !errors.Is(err, ErrRecordNotFound) {
+				t.Fatalf("err = %v, want ErrRecordNotFound", err)
+			}
+		})
+	}
+	if f.analyzer.calls != 0 {
+		t.Errorf("analyzer called %d times, want 0", f.analyzer.calls)
+	}
+}
+
+func TestAnalyzeByIDRecordDeleted(t *testing.T) {
+	f := newServiceFixture()
+	deletedAt := time.Now()
+	f.repo.records[1].DeletedAt = &deletedAt
+
+	if _, err := f.service.AnalyzeByID(1, 1, "alice"); !errors.Is(err, ErrRecordDeleted) {
+		t.Fatalf("err = %v, want ErrRecordDeleted", err)
+	}
+	if f.analyzer.calls != 0 || len(f.repo.results) != 0 {
+		t.Errorf("deleted record was analyzed")
+	}
+}
+
+func TestAnalyzeBudgetExceeded(t *testing.T) {
+	f := newServiceFixture()
+	f.repo.budgetErr = ErrBudgetExceeded
+
+	if _, err := f.service.AnalyzeByID(1, 1, "alice"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrBudgetExceeded", err)
+	}
+	if f.analyzer.calls != 0 || len(f.repo.results) != 0 || f.repo.usage[1] != 0 {
+		t.Errorf("record was analyzed after the budget was exceeded")
+	}
+}
+
+func TestAnalyzeRecordsFailedAction(t *testing.T) {
+	f := newServiceFixture(
+		models.Action{Type: actions.TypeTag, Params: map[string]interface{}{"tag": "cpu"}},
+		models.Action{Type: actions.TypeDatabase, Target: "data_records"},
+	)
+	f.executor.err = errors.New("update rejected")
+
+	result, err := f.service.AnalyzeByID(1, 1, "alice")
+	if err != nil {
+		t.Fatalf("AnalyzeByID: %v", err)
+	}
+	if len(f.executor.executed) != 2 {
+		t.Fatalf("executed %d actions, want 2", len(f.executor.executed))
+	}
+	if len(f.repo.executions) != 2 {
+		t.Fatalf("saved %d executions, want 2", len(f.repo.executions))
+	}
+	for _, execution := range f.repo.executions {
+		if execution.Status != models.ActionFailed || execution.Error != "update rejected" {
+			t.Errorf("execution = %+v, want failed with the executor error", execution)
+		}
+		if execution.AnalysisID != result.ID || execution.RecordID != 1 || execution.ExecutedBy != "alice" {
+			t.Errorf("execution = %+v, want it linked to the analysis", execution)
+		}
+	}
+}
+
+func TestAnalyzeSendsNotificationsToNotifier(t *testing.T) {
+	f := newServiceFixture(
+		models.Action{Type: actions.TypeNotification, Params: map[string]interface{}{"message": "CPU 过高"}},
+		models.Action{Type: actions.TypeNotification, Params: map[string]interface{}{}},
+	)
+
+	result, err := f.service.AnalyzeByID(1, 1, "alice")
+	if err != nil {
+		t.Fatalf("AnalyzeByID: %v", err)
+	}
+	if len(f.executor.executed) != 0 {
+		t.Errorf("executor received %d notification actions, want 0", len(f.executor.executed))
+	}
+	if len(f.notifier.notices) != 1 {
+		t.Fatalf("notifier received %d notices, want 1", len(f.notifier.notices))
+	}
+	notice := f.notifier.notices[0]
+	if notice.TenantID != 1 || notice.Message != "CPU 过高" || notice.Analysis != result ||
+		notice.Event != notification.EventAnalysisAction {
+		t.Errorf("notice = %+v", notice)
+	}
+
+	if len(f.repo.executions) != 2 {
+		t.Fatalf("saved %d executions, want 2", len(f.repo.executions))
+	}
+	if f.repo.executions[0].Status != models.ActionSucceeded {
+		t.Errorf("first execution = %+v, want succeeded", f.repo.executions[0])
+	}
+	if f.repo.executions[1].Status != models.ActionFailed || f.repo.executions[1].Error == "" {
+		t.Errorf("execution without message = %+v, want failed", f.repo.executions[1])
+	}
+}
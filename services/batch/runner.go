@@ -0,0 +1,273 @@
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+const (
+	// DefaultWorkers 默认同时分析的记录数
+	DefaultWorkers = 4
+	// DefaultRate 默认调用频率
+	DefaultRate = "60/1m"
+	// LeaseTimeout 执行任务的实例超过该时间未更新心跳时，其他实例可以认领该任务继续执行
+	LeaseTimeout = time.Minute
+	// heartbeatInterval 执行任务期间更新心跳的间隔
+	heartbeatInterval = LeaseTimeout / 4
+)
+
+// AnalyzeFunc 以 principal 的身份分析一条记录并执行建议的操作，返回已保存的分析结果
+type AnalyzeFunc func(record *models.DataRecord, principal string) (*models.AnalysisResult, error)
+
+// Runner 执行批量分析任务。所有任务共享并发数和调用频率限制。
+// 多实例部署时每个任务同一时间只由认领它的一个实例执行
+type Runner struct {
+	db      *sql.DB
+	analyze AnalyzeFunc
+	owner   string // 认领任务时使用的实例标识
+	slots   chan struct{}
+	ticker  *time.Ticker
+
+	mu      sync.Mutex
+	cancels map[int64]context.CancelFunc
+}
+
+// NewRunner 创建任务执行器，并发数由 ANALYZE_BATCH_WORKERS 配置，
+// 调用频率由 ANALYZE_BATCH_RATE 配置（如 60/1m，off 表示不限制）
+func NewRunner(db *sql.DB, analyze AnalyzeFunc) *Runner {
+	workers := DefaultWorkers
+	if value := os.Getenv("ANALYZE_BATCH_WORKERS"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			workers = n
+		} else {
+			log.Printf("忽略无效的ANALYZE_BATCH_WORKERS配置: %s", value)
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	runner := &Runner{
+		db:      db,
+		analyze: analyze,
+		owner:   fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano()),
+		slots:   make(chan struct{}, workers),
+		cancels: map[int64]context.CancelFunc{},
+	}
+
+	value := os.Getenv("ANALYZE_BATCH_RATE")
+	if value == "" {
+		value = DefaultRate
+	}
+	if value != "off" {
+		interval, err := parseRate(value)
+		if err != nil {
+			log.Printf("忽略无效的ANALYZE_BATCH_RATE配置: %v", err)
+			interval, _ = parseRate(DefaultRate)
+		}
+		runner.ticker = time.NewTicker(interval)
+	}
+	return runner
+}
+
+// parseRate 将形如 "60/1m" 的频率转换为两次调用的最小间隔
+func parseRate(value string) (time.Duration, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid rate: %s", value)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("invalid rate count: %s", value)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return 0, fmt.Errorf("invalid rate window: %s", value)
+	}
+	return window / time.Duration(count), nil
+}
+
+// Start 在后台执行租户的任务
+func (r *Runner) Start(tenantID int64, batchID int64) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	if _, running := r.cancels[batchID]; running {
+		r.mu.Unlock()
+		cancel()
+		return
+	}
+	r.cancels[batchID] = cancel
+	r.mu.Unlock()
+
+	go func() {
+		defer func() {
+			r.mu.Lock()
+			delete(r.cancels, batchID)
+			r.mu.Unlock()
+			cancel()
+		}()
+		if err := r.run(ctx, tenantID, batchID); err != nil {
+			log.Printf("批量分析任务执行失败 (ID: %d): %v", batchID, err)
+		}
+	}()
+}
+
+// Cancel 取消租户的任务，已开始分析的记录会继续完成。任务由其他实例执行时，
+// 该实例在分析下一条记录前或更新心跳时发现任务已取消并停止
+func (r *Runner) Cancel(tenantID int64, batchID int64) (bool, error) {
+	r.mu.Lock()
+	if cancel, ok := r.cancels[batchID]; ok {
+		cancel()
+	}
+	r.mu.Unlock()
+	return models.FinishAnalysisBatch(r.db, tenantID, batchID, models.BatchStatusCancelled)
+}
+
+// Resume 继续执行无人执行的未完成任务，包括服务重启前的任务和心跳超时的其他实例上的任务。
+// 实际执行前会先认领任务，已被其他实例认领的任务会被跳过
+func (r *Runner) Resume() error {
+	batches, err := models.GetUnclaimedAnalysisBatches(r.db, time.Now().Add(-LeaseTimeout))
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		log.Printf("继续执行批量分析任务 (ID: %d, 剩余: %d)", batch.ID, batch.Remaining)
+		r.Start(batch.TenantID, batch.ID)
+	}
+	return nil
+}
+
+// keepLease 定期更新任务的心跳，任务已结束或已被其他实例认领时调用 stop 停止执行
+func (r *Runner) keepLease(ctx context.Context, stop context.CancelFunc, batchID int64) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		owned, err := models.RenewAnalysisBatchLease(r.db, batchID, r.owner)
+		if err != nil {
+			log.Printf("更新批量分析任务心跳失败 (ID: %d): %v", batchID, err)
+			continue
+		}
+		if !owned {
+			log.Printf("批量分析任务已结束或由其他实例执行，停止执行 (ID: %d)", batchID)
+			stop()
+			return
+		}
+	}
+}
+
+func (r *Runner) run(ctx context.Context, tenantID int64, batchID int64) error {
+	batch, err := models.GetAnalysisBatch(r.db, tenantID, batchID)
+	if err != nil {
+		return err
+	}
+	if batch == nil {
+		return fmt.Errorf("analysis batch %d not found", batchID)
+	}
+	claimed, err := models.ClaimAnalysisBatch(r.db, tenantID, batchID, r.owner, time.Now().Add(-LeaseTimeout))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// 任务已结束或正由其他实例执行
+		return nil
+	}
+	if err := models.StartAnalysisBatch(r.db, tenantID, batchID); err != nil {
+		return err
+	}
+	recordIDs, err := models.GetPendingBatchRecordIDs(r.db, batchID)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	go r.keepLease(ctx, stop, batchID)
+
+	var wg sync.WaitGroup
+	for _, recordID := range recordIDs {
+		if !r.wait(ctx) {
+			break
+		}
+		// 任务可能已在其他实例上被取消，或心跳超时后被其他实例接管
+		owned, err := models.AnalysisBatchOwnedBy(r.db, batchID, r.owner)
+		if err != nil || !owned {
+			<-r.slots
+			stop()
+			wg.Wait()
+			return err
+		}
+		wg.Add(1)
+		go func(recordID int64) {
+			defer func() {
+				<-r.slots
+				wg.Done()
+			}()
+			r.analyzeItem(batch, recordID)
+		}(recordID)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	_, err = models.FinishAnalysisBatch(r.db, tenantID, batchID, models.BatchStatusCompleted)
+	return err
+}
+
+// wait 等待空闲的并发槽位和调用频率许可，任务被取消时返回 false
+func (r *Runner) wait(ctx context.Context) bool {
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+	if r.ticker == nil {
+		return true
+	}
+	select {
+	case <-r.ticker.C:
+		return true
+	case <-ctx.Done():
+		<-r.slots
+		return false
+	}
+}
+
+// analyzeItem 以任务创建者的身份分析任务中的一条记录并保存结果，记录须属于任务所在租户
+func (r *Runner) analyzeItem(batch *models.AnalysisBatch, recordID int64) {
+	var resultID int64
+	var message string
+
+	record, err := models.GetDataRecord(r.db, batch.TenantID, recordID)
+	switch {
+	case err != nil:
+		message = fmt.Sprintf("获取记录失败: %v", err)
+	case record == nil:
+		message = "记录未找到"
+	case record.DeletedAt != nil:
+		message = "记录已删除"
+	default:
+		result, err := r.analyze(record, batch.CreatedBy)
+		if err != nil {
+			message = err.Error()
+		} else {
+			resultID = result.ID
+		}
+	}
+
+	if err := models.CompleteBatchItem(r.db, batch.ID, recordID, resultID, message); err != nil {
+		log.Printf("更新批量分析进度失败 (批次: %d, 记录: %d): %v", batch.ID, recordID, err)
+	}
+}
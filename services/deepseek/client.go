@@ -12,8 +12,9 @@ import (
 )
 
 type Client struct {
-	apiKey  string
-	baseURL string
+	apiKey         string
+	baseURL        string
+	systemTemplate string // 覆盖默认的 system 提示词模板，%DATA% 为数据占位符
 }
 
 type ChatCompletionRequest struct {
@@ -32,13 +33,18 @@ type ChatCompletionResponse struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage struct {
+		TotalTokens int64 `json:"total_tokens"`
+	} `json:"usage"`
 }
 
 type AnalysisResponse struct {
 	Analysis    string          `json:"analysis"`
 	Suggestions []string        `json:"suggestions"`
 	Confidence  float64         `json:"confidence"`
+	Severity    int             `json:"severity"`
 	Actions     []models.Action `json:"actions"`
+	TotalTokens int64           `json:"-"` // 本次调用消耗的 token 数
 }
 
 func NewClient(apiKey string) *Client {
@@ -48,6 +54,16 @@ func NewClient(apiKey string) *Client {
 	}
 }
 
+// WithSystemTemplate 返回使用指定 system 提示词模板的客户端副本，模板为空时返回原客户端
+func (c *Client) WithSystemTemplate(template string) *Client {
+	if template == "" {
+		return c
+	}
+	copied := *c
+	copied.systemTemplate = template
+	return &copied
+}
+
 func (c *Client) AnalyzeData(prompt string, data interface{}) (*AnalysisResponse, error) {
 	dataJSON, err := json.Marshal(data)
 	if err != nil {
@@ -59,6 +75,13 @@ func (c *Client) AnalyzeData(prompt string, data interface{}) (*AnalysisResponse
 	for _, template := range prompts.DefaultTemplates() {
 		templateManager.RegisterTemplate(template)
 	}
+	if c.systemTemplate != "" {
+		templateManager.RegisterTemplate(&prompts.PromptTemplate{
+			Type:        "system",
+			Template:    c.systemTemplate,
+			Placeholder: []string{"%DATA%"},
+		})
+	}
 
 	systemPrompt, err := templateManager.GetPrompt("system", []string{string(dataJSON)})
 	if err != nil {
@@ -116,6 +139,7 @@ func (c *Client) AnalyzeData(prompt string, data interface{}) (*AnalysisResponse
 	if err := json.Unmarshal([]byte(content), &analysisResp); err != nil {
 		return nil, fmt.Errorf("error parsing analysis response from content '%s': %v", content, err)
 	}
+	analysisResp.TotalTokens = apiResp.Usage.TotalTokens
 
 	return &analysisResp, nil
 }
@@ -0,0 +1,83 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"deepseek_golang_demo/models"
+)
+
+// readCSV 第一行为表头，必须包含 content 列，可选 type 和 metadata（JSON 对象）列，
+// 其余列作为字符串字段合并到元数据中
+func (im *Importer) readCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return inputErrorf("error reading CSV header: %v", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	contentColumn, ok := columns["content"]
+	if !ok {
+		return inputErrorf("CSV header must contain a content column")
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if parseErr, ok := err.(*csv.ParseError); ok {
+				im.fail(parseErr.StartLine, parseErr.Err.Error())
+				continue
+			}
+			return inputErrorf("error reading CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(row) != len(header) {
+			im.fail(line, fmt.Sprintf("expected %d columns, got %d", len(header), len(row)))
+			continue
+		}
+
+		record := &models.DataRecord{Content: row[contentColumn]}
+		if i, ok := columns["type"]; ok {
+			record.Type = strings.TrimSpace(row[i])
+		}
+
+		metadata := map[string]interface{}{}
+		if i, ok := columns["metadata"]; ok && strings.TrimSpace(row[i]) != "" {
+			if err := json.Unmarshal([]byte(row[i]), &metadata); err != nil {
+				im.fail(line, fmt.Sprintf("metadata must be a JSON object: %v", err))
+				continue
+			}
+		}
+		for name, i := range columns {
+			if name == "content" || name == "type" || name == "metadata" || row[i] == "" {
+				continue
+			}
+			if _, exists := metadata[name]; !exists {
+				metadata[name] = row[i]
+			}
+		}
+		if record.Metadata, err = json.Marshal(metadata); err != nil {
+			im.fail(line, err.Error())
+			continue
+		}
+
+		if err := im.add(line, record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package ingest
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+// 支持的导入格式
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatLog    = "log"
+)
+
+// 日志文件的拆分方式
+const (
+	SplitNone     = "none"     // 整个文件作为一条记录
+	SplitIncident = "incident" // 每个错误事件（错误行及其后续堆栈）一条记录
+	SplitWindow   = "window"   // 按时间窗口拆分
+)
+
+const (
+	// DefaultBatchSize 每次批量插入的记录数
+	DefaultBatchSize = 500
+	// MaxContentSize data_records.content 为 TEXT 列，最多 65535 字节
+	MaxContentSize = models.MaxRecordContentSize
+	// maxReportedErrors 结果中最多保留的错误明细数量
+	maxReportedErrors = 100
+)
+
+// Options 导入选项
+type Options struct {
+	Format    string        // ndjson、csv 或 log
+	Type      string        // 未指定 type 的行使用的数据类型，日志文件默认为 log
+	Split     string        // 日志文件拆分方式，默认 none
+	Window    time.Duration // SplitWindow 的窗口长度，默认 5 分钟
+	BatchSize int
+	TenantID  int64  // 记录所属租户
+	CreatedBy string // 写入记录的创建者
+
+	// OnImported 每批记录写入数据库后调用，记录已回填ID
+	OnImported func(records []*models.DataRecord)
+}
+
+// InputError 导入数据本身无法读取，如格式或拆分方式不受支持、CSV 缺少 content 列、单行过长或请求体读取失败。
+// 调用方应作为客户端错误处理，其他错误（如数据库错误）为服务端错误
+type InputError struct {
+	Message string
+}
+
+func (e *InputError) Error() string {
+	return e.Message
+}
+
+// inputErrorf 按格式创建 InputError
+func inputErrorf(format string, args ...interface{}) error {
+	return &InputError{Message: fmt.Sprintf(format, args...)}
+}
+
+// ValidFormat 判断是否为支持的导入格式
+func ValidFormat(format string) bool {
+	return format == FormatNDJSON || format == FormatCSV || format == FormatLog
+}
+
+// ValidSplit 判断是否为支持的日志拆分方式，空字符串表示默认的 none
+func ValidSplit(split string) bool {
+	return split == "" || split == SplitNone || split == SplitIncident || split == SplitWindow
+}
+
+// LineError 某一行导入失败的原因，日志文件中为记录起始行
+type LineError struct {
+	Source string `json:"source,omitempty"`
+	Line   int    `json:"line"`
+	Error  string `json:"error"`
+}
+
+// Result 导入结果
+type Result struct {
+	Source   string      `json:"source,omitempty"`
+	Imported int         `json:"imported"`
+	Failed   int         `json:"failed"`
+	Errors   []LineError `json:"errors"`
+}
+
+// pendingRecord 解析出的一条记录及其在源文件中的行号
+type pendingRecord struct {
+	line   int
+	record *models.DataRecord
+}
+
+// Importer 将解析出的记录分批写入数据库
+type Importer struct {
+	db      *sql.DB
+	options Options
+	source  string
+	batch   []pendingRecord
+	result  *Result
+}
+
+// DetectFormat 根据文件名推断导入格式，无法识别的按日志文件处理
+func DetectFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	case ".csv":
+		return FormatCSV
+	default:
+		return FormatLog
+	}
+}
+
+// Import 从 r 流式读取数据并导入，source 为文件名，用于错误明细和日志记录的元数据。
+// 单行的解析或校验错误记录在结果中，不会中断导入；数据无法读取时返回 *InputError，数据库错误同样中止导入并返回
+func Import(db *sql.DB, r io.Reader, source string, options Options) (*Result, error) {
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+	if options.Split == "" {
+		options.Split = SplitNone
+	}
+	if options.Window <= 0 {
+		options.Window = 5 * time.Minute
+	}
+
+	importer := &Importer{
+		db:      db,
+		options: options,
+		source:  source,
+		result:  &Result{Source: source, Errors: []LineError{}},
+	}
+
+	var err error
+	switch options.Format {
+	case FormatNDJSON:
+		err = importer.readNDJSON(r)
+	case FormatCSV:
+		err = importer.readCSV(r)
+	case FormatLog:
+		err = importer.readLog(r)
+	default:
+		return nil, inputErrorf("unsupported import format: %s", options.Format)
+	}
+	if err == nil {
+		err = importer.flush()
+	}
+	return importer.result, err
+}
+
+// add 校验一条记录，合法的加入当前批次，批次满时写入数据库
+func (im *Importer) add(line int, record *models.DataRecord) error {
+	if record.Type == "" {
+		record.Type = im.options.Type
+	}
+	if record.Type == "" {
+		im.fail(line, "type is required")
+		return nil
+	}
+	if !models.IsValidRecordType(record.Type) {
+		im.fail(line, fmt.Sprintf("unsupported type: %s", record.Type))
+		return nil
+	}
+	if strings.TrimSpace(record.Content) == "" {
+		im.fail(line, "content is required")
+		return nil
+	}
+	if len(record.Content) > MaxContentSize {
+		im.fail(line, fmt.Sprintf("content exceeds %d bytes", MaxContentSize))
+		return nil
+	}
+	if err := models.PrepareRecordMetadata(record); err != nil {
+		im.fail(line, err.Error())
+		return nil
+	}
+
+	im.batch = append(im.batch, pendingRecord{line: line, record: record})
+	if len(im.batch) >= im.options.BatchSize {
+		return im.flush()
+	}
+	return nil
+}
+
+// flush 写入当前批次
+func (im *Importer) flush() error {
+	if len(im.batch) == 0 {
+		return nil
+	}
+
+	records := make([]*models.DataRecord, len(im.batch))
+	for i, pending := range im.batch {
+		records[i] = pending.record
+		records[i].TenantID = im.options.TenantID
+		records[i].CreatedBy = im.options.CreatedBy
+		records[i].UpdatedBy = im.options.CreatedBy
+	}
+	if err := models.CreateDataRecords(im.db, records); err != nil {
+		return fmt.Errorf("error importing records from line %d: %v", im.batch[0].line, err)
+	}
+
+	im.result.Imported += len(records)
+	im.batch = im.batch[:0]
+	if im.options.OnImported != nil {
+		im.options.OnImported(records)
+	}
+	return nil
+}
+
+// fail 记录一行导入失败
+func (im *Importer) fail(line int, message string) {
+	im.result.Failed++
+	if len(im.result.Errors) < maxReportedErrors {
+		im.result.Errors = append(im.result.Errors, LineError{Source: im.source, Line: line, Error: message})
+	}
+}
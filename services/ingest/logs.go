@@ -0,0 +1,218 @@
+package ingest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+var (
+	// logTimestampPattern 行首的时间戳，如 2024-01-01 12:00:00,123、[2024-01-01T12:00:00Z]
+	logTimestampPattern = regexp.MustCompile(`^\[?(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?)`)
+	// logLevelPattern 日志级别，取行中第一个匹配
+	logLevelPattern = regexp.MustCompile(`(?i)\b(FATAL|PANIC|CRITICAL|ERROR|WARN(?:ING)?|INFO|DEBUG|TRACE)\b`)
+)
+
+// logTimestampLayouts 时间戳格式，日期和时间之间的空格先统一为 T
+var logTimestampLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// logChunk 正在累积的一段日志，对应一条记录
+type logChunk struct {
+	lines     []string
+	size      int
+	startLine int
+	endLine   int
+	level     string
+	startTime *time.Time
+	endTime   *time.Time
+	window    time.Time
+}
+
+// readLog 按拆分方式将日志文件转换为记录，单段日志超过内容上限时按行切分
+func (im *Importer) readLog(r io.Reader) error {
+	recordType := im.options.Type
+	if recordType == "" {
+		recordType = "log"
+	}
+	split := im.options.Split
+	if !ValidSplit(split) {
+		return inputErrorf("unsupported split mode: %s", split)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxContentSize)
+
+	var chunk *logChunk
+	emit := func() error {
+		if chunk == nil {
+			return nil
+		}
+		record, err := im.logRecord(recordType, chunk)
+		chunk = nil
+		if err != nil {
+			return err
+		}
+		return im.add(record.line, record.record)
+	}
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" && chunk == nil {
+			continue
+		}
+
+		timestamp := parseLogTimestamp(text)
+		level := ""
+		if match := logLevelPattern.FindStringSubmatch(text); match != nil {
+			level = normalizeLogLevel(match[1])
+		}
+
+		switch split {
+		case SplitIncident:
+			continuation := timestamp == nil && level == "" || strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")
+			if continuation {
+				// 堆栈等后续行归入当前事件，不属于任何事件的行忽略
+				if chunk == nil {
+					continue
+				}
+			} else {
+				if err := emit(); err != nil {
+					return err
+				}
+				if !isIncidentLevel(level) {
+					continue
+				}
+			}
+		case SplitWindow:
+			if timestamp != nil && chunk != nil && !timestamp.Truncate(im.options.Window).Equal(chunk.window) {
+				if err := emit(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if chunk != nil && chunk.size+len(text)+1 > MaxContentSize {
+			if err := emit(); err != nil {
+				return err
+			}
+		}
+		if chunk == nil {
+			chunk = &logChunk{startLine: line}
+			if timestamp != nil {
+				chunk.window = timestamp.Truncate(im.options.Window)
+			}
+		}
+		chunk.append(line, text, timestamp, level)
+	}
+	if err := scanner.Err(); err != nil {
+		return inputErrorf("error reading line %d: %v", line+1, err)
+	}
+	return emit()
+}
+
+func (c *logChunk) append(line int, text string, timestamp *time.Time, level string) {
+	c.lines = append(c.lines, text)
+	c.size += len(text) + 1
+	c.endLine = line
+	if timestamp != nil {
+		if c.startTime == nil {
+			c.startTime = timestamp
+		}
+		c.endTime = timestamp
+	}
+	if logLevelRank(level) > logLevelRank(c.level) {
+		c.level = level
+	}
+}
+
+// logRecord 生成日志段对应的记录，元数据包含来源文件、行号范围、时间范围和最高日志级别
+func (im *Importer) logRecord(recordType string, chunk *logChunk) (*pendingRecord, error) {
+	metadata := map[string]interface{}{
+		"startLine": chunk.startLine,
+		"endLine":   chunk.endLine,
+	}
+	if im.source != "" {
+		metadata["source"] = im.source
+	}
+	if chunk.level != "" {
+		metadata["level"] = chunk.level
+	}
+	if chunk.startTime != nil {
+		metadata["startTime"] = chunk.startTime.Format(time.RFC3339Nano)
+		metadata["endTime"] = chunk.endTime.Format(time.RFC3339Nano)
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding log metadata: %v", err)
+	}
+
+	return &pendingRecord{
+		line: chunk.startLine,
+		record: &models.DataRecord{
+			Type:     recordType,
+			Content:  strings.TrimRight(strings.Join(chunk.lines, "\n"), "\n"),
+			Metadata: encoded,
+		},
+	}, nil
+}
+
+// parseLogTimestamp 解析行首时间戳，没有时间戳时返回 nil
+func parseLogTimestamp(text string) *time.Time {
+	match := logTimestampPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+	value := strings.Replace(match[1], " ", "T", 1)
+	value = strings.Replace(value, ",", ".", 1)
+	for _, layout := range logTimestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// normalizeLogLevel 统一日志级别的写法
+func normalizeLogLevel(level string) string {
+	level = strings.ToUpper(level)
+	if level == "WARNING" {
+		return "WARN"
+	}
+	return level
+}
+
+// logLevelRank 日志级别的严重程度，用于取日志段中的最高级别
+func logLevelRank(level string) int {
+	switch level {
+	case "TRACE":
+		return 1
+	case "DEBUG":
+		return 2
+	case "INFO":
+		return 3
+	case "WARN":
+		return 4
+	case "ERROR":
+		return 5
+	case "CRITICAL", "FATAL", "PANIC":
+		return 6
+	}
+	return 0
+}
+
+// isIncidentLevel 是否为开始一个错误事件的日志级别
+func isIncidentLevel(level string) bool {
+	return logLevelRank(level) >= logLevelRank("ERROR")
+}
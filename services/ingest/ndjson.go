@@ -0,0 +1,48 @@
+package ingest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"deepseek_golang_demo/models"
+)
+
+// ndjsonRecord NDJSON 中的一行，字段与 POST /api/records 的请求体相同
+type ndjsonRecord struct {
+	Type     string          `json:"type"`
+	Content  string          `json:"content"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// readNDJSON 每行一个 JSON 对象，空行忽略
+func (im *Importer) readNDJSON(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	// 单行最大为内容上限加上字段和转义的余量
+	scanner.Buffer(make([]byte, 64*1024), 4*MaxContentSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var row ndjsonRecord
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			im.fail(line, fmt.Sprintf("invalid JSON: %v", err))
+			continue
+		}
+		record := &models.DataRecord{Type: row.Type, Content: row.Content, Metadata: row.Metadata}
+		if err := im.add(line, record); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return inputErrorf("error reading line %d: %v", line+1, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultAckLinkTTL = 72 * time.Hour
+
+// AckURL 生成带签名的通知确认链接，未配置 PUBLIC_BASE_URL 或 NOTIFY_ACK_SECRET 时返回空字符串
+func AckURL(notificationID int64) string {
+	baseURL := strings.TrimRight(os.Getenv("PUBLIC_BASE_URL"), "/")
+	secret := os.Getenv("NOTIFY_ACK_SECRET")
+	if baseURL == "" || secret == "" || notificationID == 0 {
+		return ""
+	}
+
+	expires := time.Now().Add(ackLinkTTL()).Unix()
+	return fmt.Sprintf("%s/api/notifications/%d/ack?expires=%d&signature=%s",
+		baseURL, notificationID, expires, signAck(secret, notificationID, expires))
+}
+
+// VerifyAckSignature 校验确认链接的签名和有效期
+func VerifyAckSignature(notificationID int64, expires int64, signature string) bool {
+	secret := os.Getenv("NOTIFY_ACK_SECRET")
+	if secret == "" || time.Now().Unix() > expires {
+		return false
+	}
+	expected := signAck(secret, notificationID, expires)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func signAck(secret string, notificationID int64, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(notificationID, 10) + "." + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ackLinkTTL 读取 NOTIFY_ACK_TTL 配置的确认链接有效期
+func ackLinkTTL() time.Duration {
+	value := os.Getenv("NOTIFY_ACK_TTL")
+	if value == "" {
+		return defaultAckLinkTTL
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Printf("无效的NOTIFY_ACK_TTL配置 %q，使用默认值 %s", value, defaultAckLinkTTL)
+		return defaultAckLinkTTL
+	}
+	return ttl
+}
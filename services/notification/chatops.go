@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// severityLevel 严重程度的展示名称和各平台使用的颜色
+type severityLevel struct {
+	Label    string
+	Hex      string // Slack / 钉钉
+	Template string // 飞书卡片标题模板颜色
+	WeCom    string // 企业微信 markdown 字体颜色
+}
+
+var severityLevels = map[int]severityLevel{
+	0: {Label: "未知", Hex: "#909399", Template: "grey", WeCom: "comment"},
+	1: {Label: "提示", Hex: "#2EB67D", Template: "green", WeCom: "info"},
+	2: {Label: "低", Hex: "#36A2EB", Template: "blue", WeCom: "info"},
+	3: {Label: "中", Hex: "#ECB22E", Template: "yellow", WeCom: "warning"},
+	4: {Label: "高", Hex: "#FF8C00", Template: "orange", WeCom: "warning"},
+	5: {Label: "严重", Hex: "#E01E5A", Template: "red", WeCom: "warning"},
+}
+
+// noticeSeverity 返回通知的严重程度，操作参数中的 severity 优先于分析结果
+func noticeSeverity(notice *Notice) int {
+	severity := 0
+	if notice.Analysis != nil {
+		severity = notice.Analysis.Severity
+	}
+	if value, ok := notice.Params["severity"].(float64); ok {
+		severity = int(value)
+	}
+	if severity < 0 || severity > 5 {
+		severity = 0
+	}
+	return severity
+}
+
+// noticeTitle 生成通知标题
+func noticeTitle(notice *Notice) string {
+	level := severityLevels[noticeSeverity(notice)]
+	if notice.Record != nil {
+		return fmt.Sprintf("[%s] %s 数据分析通知 #%d", level.Label, notice.Record.Type, notice.Record.ID)
+	}
+	return fmt.Sprintf("[%s] 数据分析通知", level.Label)
+}
+
+// recordLink 返回记录详情链接，未配置 PUBLIC_BASE_URL 时返回空字符串
+func recordLink(notice *Notice) string {
+	baseURL := strings.TrimRight(os.Getenv("PUBLIC_BASE_URL"), "/")
+	if baseURL == "" || notice.Record == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/records/%d", baseURL, notice.Record.ID)
+}
+
+// noticeSuggestions 返回分析结果中的建议列表
+func noticeSuggestions(notice *Notice) []string {
+	if notice.Analysis == nil {
+		return nil
+	}
+	return notice.Analysis.Suggestions
+}
+
+// markdownBody 生成通用的 markdown 通知正文
+func markdownBody(notice *Notice) string {
+	var b strings.Builder
+	b.WriteString(notice.Message)
+	if notice.Analysis != nil {
+		fmt.Fprintf(&b, "\n\n**分析结果**：%s", notice.Analysis.Analysis)
+		fmt.Fprintf(&b, "\n\n**置信度**：%.2f", notice.Analysis.Confidence)
+	}
+	if suggestions := noticeSuggestions(notice); len(suggestions) > 0 {
+		b.WriteString("\n\n**建议**：")
+		for _, suggestion := range suggestions {
+			fmt.Fprintf(&b, "\n- %s", suggestion)
+		}
+	}
+	if link := AckURL(notice.NotificationID); link != "" {
+		fmt.Fprintf(&b, "\n\n[确认通知](%s)", link)
+	}
+	return b.String()
+}
+
+// webhookURL 返回通知地址，操作参数中的 url 优先于环境变量配置
+func webhookURL(notice *Notice, envKey string) (string, error) {
+	if url, ok := notice.Params["url"].(string); ok && url != "" {
+		return url, nil
+	}
+	if url := os.Getenv(envKey); url != "" {
+		return url, nil
+	}
+	return "", fmt.Errorf("未配置%s通知地址 (%s)", notice.Channel, envKey)
+}
+
+// postJSON 以 JSON 格式发送请求，并将响应体解析到 out（可为 nil）
+func postJSON(url string, payload interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化请求体失败: %v", err)
+	}
+
+	client := &http.Client{Timeout: webhookTimeout()}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(respBody))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("解析响应失败: %v, 响应: %s", err, string(respBody))
+		}
+	}
+	return nil
+}
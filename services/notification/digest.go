@@ -0,0 +1,151 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+// EventDigest 汇总通知事件
+const EventDigest = "notification.digest"
+
+// DigestInterval 读取 NOTIFY_DIGEST 配置的汇总周期（hourly/daily），未开启时返回 0
+func DigestInterval() time.Duration {
+	switch os.Getenv("NOTIFY_DIGEST") {
+	case "hourly":
+		return time.Hour
+	case "daily":
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// RunDigestLoop 按周期发送汇总通知，在整点（或零点）触发，阻塞直到进程退出
+func RunDigestLoop(db *sql.DB, interval time.Duration) {
+	for {
+		now := routingNow()
+		next := now.Truncate(interval).Add(interval)
+		if interval == 24*time.Hour {
+			next = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+		}
+		time.Sleep(next.Sub(now))
+
+		if err := SendDigests(db); err != nil {
+			log.Printf("发送汇总通知失败: %v", err)
+		}
+	}
+}
+
+// SendDigests 为每个租户发送汇总通知
+func SendDigests(db *sql.DB) error {
+	tenants, err := models.ListTenants(db)
+	if err != nil {
+		return fmt.Errorf("获取租户失败: %v", err)
+	}
+
+	var failed int
+	for _, tenant := range tenants {
+		if err := sendTenantDigests(db, tenant.ID); err != nil {
+			log.Printf("发送汇总通知失败 (租户: %s): %v", tenant.Slug, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d 个租户的汇总通知发送失败", failed)
+	}
+	return nil
+}
+
+// sendTenantDigests 将租户被抑制的通知按渠道和接收人合并为汇总通知发送
+func sendTenantDigests(db *sql.DB, tenantID int64) error {
+	notifications, err := models.GetSuppressedNotifications(db, tenantID)
+	if err != nil {
+		return fmt.Errorf("获取待汇总通知失败: %v", err)
+	}
+
+	type digestKey struct{ channel, recipient string }
+	batches := make(map[digestKey][]models.Notification)
+	var keys []digestKey
+	for _, n := range notifications {
+		key := digestKey{n.Channel, n.Recipient}
+		if _, ok := batches[key]; !ok {
+			keys = append(keys, key)
+		}
+		batches[key] = append(batches[key], n)
+	}
+
+	var failed int
+	for _, key := range keys {
+		batch := batches[key]
+		if key.recipient == "" {
+			continue
+		}
+
+		ids := make([]int64, len(batch))
+		for i, n := range batch {
+			ids[i] = n.ID
+		}
+
+		// 先认领再发送，避免多个实例重复发送同一批通知
+		claimed, err := models.MarkNotificationsDigested(db, tenantID, ids)
+		if err != nil {
+			return fmt.Errorf("更新通知状态失败: %v", err)
+		}
+		if claimed != int64(len(ids)) {
+			log.Printf("汇总通知已被其他实例处理 (渠道: %s, 接收人: %s)", key.channel, key.recipient)
+			continue
+		}
+
+		notice := &Notice{
+			TenantID: tenantID,
+			Event:    EventDigest,
+			Channel:  key.channel,
+			Message:  digestMessage(batch),
+			Params:   recipientParams(nil, key.channel, key.recipient),
+		}
+		if err := Send(notice); err != nil {
+			log.Printf("发送汇总通知失败 (渠道: %s, 接收人: %s): %v", key.channel, key.recipient, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d 条汇总通知发送失败", failed)
+	}
+	return nil
+}
+
+// digestMessage 生成汇总通知正文，相同去重键的通知合并为一行并显示次数
+func digestMessage(batch []models.Notification) string {
+	type entry struct {
+		notification models.Notification
+		count        int
+	}
+	var entries []*entry
+	byKey := make(map[string]*entry)
+	for _, n := range batch {
+		if e, ok := byKey[n.DedupKey]; ok && n.DedupKey != "" {
+			e.count++
+			continue
+		}
+		e := &entry{notification: n, count: 1}
+		byKey[n.DedupKey] = e
+		entries = append(entries, e)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "以下 %d 条通知因重复或频率限制被合并发送：", len(batch))
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\n- [%s] 记录 #%d: %s", e.notification.CreatedAt.Format("01-02 15:04"), e.notification.RecordID, e.notification.Message)
+		if e.count > 1 {
+			fmt.Fprintf(&b, " (共 %d 次)", e.count)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// dingTalkResponse 钉钉机器人接口响应
+type dingTalkResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// sendDingTalk 通过钉钉自定义机器人发送 ActionCard 通知
+func sendDingTalk(notice *Notice) error {
+	webhook, err := webhookURL(notice, "DINGTALK_WEBHOOK_URL")
+	if err != nil {
+		return err
+	}
+
+	if secret := os.Getenv("DINGTALK_SECRET"); secret != "" {
+		webhook, err = signDingTalkURL(webhook, secret, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	level := severityLevels[noticeSeverity(notice)]
+	title := noticeTitle(notice)
+	text := fmt.Sprintf("### <font color=\"%s\">%s</font>\n\n%s", level.Hex, title, markdownBody(notice))
+
+	card := map[string]interface{}{
+		"title": title,
+		"text":  text,
+	}
+	if link := recordLink(notice); link != "" {
+		card["singleTitle"] = "查看记录"
+		card["singleURL"] = link
+	}
+
+	var resp dingTalkResponse
+	if err := postJSON(webhook, map[string]interface{}{
+		"msgtype":    "actionCard",
+		"actionCard": card,
+	}, &resp); err != nil {
+		return fmt.Errorf("发送钉钉通知失败: %v", err)
+	}
+	if resp.ErrCode != 0 {
+		return fmt.Errorf("发送钉钉通知失败: %d %s", resp.ErrCode, resp.ErrMsg)
+	}
+	return nil
+}
+
+// signDingTalkURL 为加签的钉钉机器人地址追加 timestamp 和 sign 参数
+func signDingTalkURL(webhook string, secret string, now time.Time) (string, error) {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return "", fmt.Errorf("无效的钉钉机器人地址: %v", err)
+	}
+
+	timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+
+	query := u.Query()
+	query.Set("timestamp", timestamp)
+	query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"deepseek_golang_demo/models"
+)
+
+// Dispatcher 使用数据库中的路由规则和发送记录分发通知
+type Dispatcher struct {
+	db *sql.DB
+}
+
+func NewDispatcher(db *sql.DB) *Dispatcher {
+	return &Dispatcher{db: db}
+}
+
+// Notify 分发通知，规则同 Dispatch
+func (d *Dispatcher) Notify(notice *Notice) error {
+	return Dispatch(d.db, notice)
+}
+
+// Dispatch 按路由规则将通知发送给匹配的接收组，并记录每条通知的发送状态。
+// 接收人只由路由规则决定，通知自身指定的渠道和参数中的接收人不会被使用；
+// 没有匹配的路由规则时发送给 NOTIFY_DEFAULT_GROUP 指定的接收组，未配置时丢弃通知
+func Dispatch(db *sql.DB, notice *Notice) error {
+	groups, err := Route(db, notice)
+	if err != nil {
+		return fmt.Errorf("通知路由失败: %v", err)
+	}
+
+	if len(groups) == 0 {
+		group, err := defaultGroup(db, notice.TenantID)
+		if err != nil {
+			return fmt.Errorf("获取默认通知接收组失败: %v", err)
+		}
+		if group == nil {
+			log.Printf("没有匹配的通知路由规则，也未配置默认接收组，丢弃通知 (租户: %d, 渠道: %s)",
+				notice.TenantID, notice.Channel)
+			return nil
+		}
+		log.Printf("没有匹配的通知路由规则，发送给默认接收组: %s", group.Name)
+		groups = append(groups, *group)
+	}
+
+	var failed int
+	for _, group := range groups {
+		for _, recipient := range group.Recipients {
+			routed := *notice
+			routed.Channel = group.Channel
+			routed.GroupID = group.ID
+			routed.Params = recipientParams(notice.Params, group.Channel, recipient)
+			if _, err := deliver(db, &routed); err != nil {
+				log.Printf("发送通知失败 (接收组: %s, 渠道: %s): %v", group.Name, group.Channel, err)
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d 条通知发送失败", failed)
+	}
+	return nil
+}
+
+// deliver 创建通知记录、发送通知并更新发送状态。重复或超过频率限制的通知只记录为
+// suppressed，等待汇总发送；设置了 NoSuppress 的通知总是立即发送
+func deliver(db *sql.DB, notice *Notice) (*models.Notification, error) {
+	n := &models.Notification{
+		TenantID:        notice.TenantID,
+		GroupID:         notice.GroupID,
+		Channel:         notice.Channel,
+		Recipient:       noticeRecipient(notice),
+		Message:         notice.Message,
+		Severity:        noticeSeverity(notice),
+		EscalationLevel: notice.EscalationLevel,
+	}
+	if notice.Record != nil {
+		n.RecordID = notice.Record.ID
+	}
+
+	key, err := dedupKey(db, notice, n.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	n.DedupKey = key
+
+	var reason string
+	if !notice.NoSuppress {
+		if reason, err = shouldSuppress(db, notice, n.Recipient, key); err != nil {
+			return nil, fmt.Errorf("检查通知频率限制失败: %v", err)
+		}
+	}
+	if reason != "" {
+		n.Status = models.NotificationSuppressed
+	}
+
+	if err := models.CreateNotification(db, n); err != nil {
+		return nil, fmt.Errorf("failed to create notification: %v", err)
+	}
+	if reason != "" {
+		log.Printf("通知已抑制 (ID: %d, 渠道: %s, 接收人: %s): %s", n.ID, n.Channel, n.Recipient, reason)
+		return n, nil
+	}
+
+	notice.NotificationID = n.ID
+	if err := Send(notice); err != nil {
+		if updateErr := models.UpdateNotificationStatus(db, n.TenantID, n.ID, models.NotificationFailed); updateErr != nil {
+			log.Printf("Failed to update notification status: %v", updateErr)
+		}
+		n.Status = models.NotificationFailed
+		return n, fmt.Errorf("failed to send notification: %v", err)
+	}
+
+	if err := models.UpdateNotificationStatus(db, n.TenantID, n.ID, models.NotificationSent); err != nil {
+		return n, fmt.Errorf("failed to update notification status: %v", err)
+	}
+	n.Status = models.NotificationSent
+	return n, nil
+}
@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"time"
+)
+
+// sendEmail 发送邮件通知，主题和正文由通知模板渲染，同时包含纯文本和 HTML 两个版本
+func sendEmail(notice *Notice) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+
+	to, ok := notice.Params["to"].(string)
+	if !ok {
+		return fmt.Errorf("无效的收件人参数")
+	}
+	toAddr, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("无效的收件人地址: %v", err)
+	}
+
+	fromAddr := os.Getenv("SMTP_FROM")
+	if fromAddr == "" {
+		fromAddr = smtpUser
+	}
+	from := &mail.Address{Name: os.Getenv("SMTP_FROM_NAME"), Address: fromAddr}
+
+	subject, err := renderText(notice, templateSubject, "系统通知")
+	if err != nil {
+		return err
+	}
+	text, err := renderText(notice, templateText, notice.Message)
+	if err != nil {
+		return err
+	}
+	html, err := renderHTML(notice)
+	if err != nil {
+		return err
+	}
+
+	msg, err := buildEmail(from, toAddr, subject, text, html)
+	if err != nil {
+		return err
+	}
+
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	if err := smtp.SendMail(smtpHost+":"+smtpPort, auth, from.Address, []string{toAddr.Address}, msg); err != nil {
+		log.Printf("发送邮件失败: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// buildEmail 构建 MIME 邮件，主题和发件人名称按 RFC 2047 编码，正文使用 quoted-printable 编码
+func buildEmail(from *mail.Address, to *mail.Address, subject string, text string, html string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if html == "" {
+		buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&buf, text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	}
+	for _, part := range parts {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("构建邮件正文失败: %v", err)
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("构建邮件正文失败: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable 以 quoted-printable 编码写入正文
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("编码邮件正文失败: %v", err)
+	}
+	return qp.Close()
+}
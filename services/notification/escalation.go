@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+// EventEscalation 通知升级事件
+const EventEscalation = "notification.escalation"
+
+// RunEscalationLoop 定期检查未确认的通知并按升级策略升级，阻塞直到进程退出
+func RunEscalationLoop(db *sql.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := Escalate(db); err != nil {
+			log.Printf("通知升级失败: %v", err)
+		}
+	}
+}
+
+// Escalate 按各租户的升级策略升级超时未确认的通知
+func Escalate(db *sql.DB) error {
+	tenants, err := models.ListTenants(db)
+	if err != nil {
+		return err
+	}
+
+	for _, tenant := range tenants {
+		if err := escalateTenant(db, tenant.ID); err != nil {
+			log.Printf("通知升级失败 (租户: %s): %v", tenant.Slug, err)
+		}
+	}
+	return nil
+}
+
+// escalateTenant 对租户超时未确认的通知执行升级：向策略的目标接收组重新发送通知，并记录升级链
+func escalateTenant(db *sql.DB, tenantID int64) error {
+	policies, err := models.ListEscalationPolicies(db, tenantID, true)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		deadline := time.Now().Add(-time.Duration(policy.AckTimeoutMinutes) * time.Minute)
+		pending, err := models.GetUnacknowledgedNotifications(db, tenantID, policy.MinSeverity, policy.Level-1, deadline)
+		if err != nil {
+			return fmt.Errorf("获取未确认通知失败: %v", err)
+		}
+
+		for _, n := range pending {
+			if policy.SourceGroupID != 0 && n.GroupID != policy.SourceGroupID {
+				continue
+			}
+			if err := escalateNotification(db, policy, n); err != nil {
+				log.Printf("升级通知失败 (通知ID: %d, 策略: %s): %v", n.ID, policy.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// escalateNotification 按策略升级单条通知
+func escalateNotification(db *sql.DB, policy models.EscalationPolicy, n models.Notification) error {
+	group, err := models.GetNotificationGroup(db, policy.TenantID, policy.TargetGroupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("升级目标接收组不存在: %d", policy.TargetGroupID)
+	}
+
+	// 先标记原通知已升级，多个实例同时运行时只有一个会继续
+	claimed, err := models.MarkNotificationEscalated(db, n.TenantID, n.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	record, err := models.GetDataRecord(db, n.TenantID, n.RecordID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("[升级 L%d] 通知 #%d 在 %d 分钟内未被确认：%s",
+		policy.Level, n.ID, policy.AckTimeoutMinutes, n.Message)
+
+	for _, recipient := range group.Recipients {
+		notice := &Notice{
+			TenantID:        n.TenantID,
+			Event:           EventEscalation,
+			Channel:         group.Channel,
+			Message:         message,
+			Params:          recipientParams(map[string]interface{}{"severity": float64(n.Severity)}, group.Channel, recipient),
+			Record:          record,
+			GroupID:         group.ID,
+			EscalationLevel: policy.Level,
+			NoSuppress:      true,
+		}
+
+		escalated, err := deliver(db, notice)
+		if escalated != nil {
+			if recordErr := models.CreateNotificationEscalation(db, &models.NotificationEscalation{
+				NotificationID:          n.ID,
+				EscalatedNotificationID: escalated.ID,
+				PolicyID:                policy.ID,
+				Level:                   policy.Level,
+			}); recordErr != nil {
+				log.Printf("记录通知升级失败 (通知ID: %d): %v", n.ID, recordErr)
+			}
+		}
+		if err != nil {
+			log.Printf("发送升级通知失败 (通知ID: %d, 接收人: %s): %v", n.ID, recipient, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// feishuResponse 飞书自定义机器人接口响应
+type feishuResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// sendFeishu 通过飞书/Lark 自定义机器人发送消息卡片
+func sendFeishu(notice *Notice) error {
+	webhook, err := webhookURL(notice, "FEISHU_WEBHOOK_URL")
+	if err != nil {
+		return err
+	}
+
+	level := severityLevels[noticeSeverity(notice)]
+
+	elements := []map[string]interface{}{
+		{
+			"tag":  "div",
+			"text": map[string]interface{}{"tag": "lark_md", "content": markdownBody(notice)},
+		},
+	}
+	if link := recordLink(notice); link != "" {
+		elements = append(elements, map[string]interface{}{
+			"tag": "action",
+			"actions": []map[string]interface{}{
+				{
+					"tag":  "button",
+					"text": map[string]interface{}{"tag": "plain_text", "content": "查看记录"},
+					"url":  link,
+					"type": "primary",
+				},
+			},
+		})
+	}
+
+	payload := map[string]interface{}{
+		"msg_type": "interactive",
+		"card": map[string]interface{}{
+			"header": map[string]interface{}{
+				"title":    map[string]interface{}{"tag": "plain_text", "content": noticeTitle(notice)},
+				"template": level.Template,
+			},
+			"elements": elements,
+		},
+	}
+
+	if secret := os.Getenv("FEISHU_SECRET"); secret != "" {
+		timestamp, sign := signFeishu(secret, time.Now())
+		payload["timestamp"] = timestamp
+		payload["sign"] = sign
+	}
+
+	var resp feishuResponse
+	if err := postJSON(webhook, payload, &resp); err != nil {
+		return fmt.Errorf("发送飞书通知失败: %v", err)
+	}
+	if resp.Code != 0 {
+		return fmt.Errorf("发送飞书通知失败: %d %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// signFeishu 计算飞书机器人签名校验所需的 timestamp 和 sign
+func signFeishu(secret string, now time.Time) (string, string) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(timestamp+"\n"+secret))
+	return timestamp, base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
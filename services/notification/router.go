@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+// Route 根据通知所属租户的路由规则选择接收组。所有匹配的规则都会生效，同一接收组只返回一次
+func Route(db *sql.DB, notice *Notice) ([]models.NotificationGroup, error) {
+	routes, err := models.ListNotificationRoutes(db, notice.TenantID, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return nil, nil
+	}
+
+	var tags map[string]bool
+	if notice.Record != nil {
+		recordTags, err := models.GetTagsByRecordID(db, notice.TenantID, notice.Record.ID)
+		if err != nil {
+			return nil, fmt.Errorf("获取记录标签失败: %v", err)
+		}
+		tags = make(map[string]bool, len(recordTags))
+		for _, tag := range recordTags {
+			tags[tag.TagName] = true
+		}
+	}
+
+	severity := noticeSeverity(notice)
+	hour := routingNow().Hour()
+
+	var groups []models.NotificationGroup
+	seen := make(map[int64]bool)
+	for _, route := range routes {
+		if !routeMatches(route, notice, tags, severity, hour) || seen[route.GroupID] {
+			continue
+		}
+		seen[route.GroupID] = true
+
+		group, err := models.GetNotificationGroup(db, notice.TenantID, route.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("获取通知接收组失败 (ID: %d): %v", route.GroupID, err)
+		}
+		if group != nil {
+			groups = append(groups, *group)
+		}
+	}
+	return groups, nil
+}
+
+// defaultGroup 返回租户中名称为 NOTIFY_DEFAULT_GROUP 的接收组，用于没有匹配路由规则的通知。
+// 未配置或租户没有该接收组时返回 nil
+func defaultGroup(db *sql.DB, tenantID int64) (*models.NotificationGroup, error) {
+	name := os.Getenv("NOTIFY_DEFAULT_GROUP")
+	if name == "" {
+		return nil, nil
+	}
+	groups, err := models.ListNotificationGroups(db, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		if groups[i].Name == name {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// routeMatches 判断路由规则是否匹配通知
+func routeMatches(route models.NotificationRoute, notice *Notice, tags map[string]bool, severity int, hour int) bool {
+	if route.RecordType != "" && (notice.Record == nil || notice.Record.Type != route.RecordType) {
+		return false
+	}
+	if route.Tag != "" && !tags[route.Tag] {
+		return false
+	}
+	if severity < route.MinSeverity {
+		return false
+	}
+	return hourInWindow(hour, route.StartHour, route.EndHour)
+}
+
+// hourInWindow 判断小时是否落在 [start, end) 时段内，end 小于 start 时表示跨越午夜
+func hourInWindow(hour int, start int, end int) bool {
+	if start == end {
+		return true
+	}
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// routingNow 返回路由判断使用的当前时间，时区由 NOTIFY_TIMEZONE 配置
+func routingNow() time.Time {
+	if name := os.Getenv("NOTIFY_TIMEZONE"); name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return time.Now().In(loc)
+		}
+	}
+	return time.Now()
+}
+
+// recipientParams 复制通知参数并按渠道填入接收人，参数中原有的接收人会被去掉
+func recipientParams(params map[string]interface{}, channel string, recipient string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(params)+1)
+	for key, value := range params {
+		if key == "to" || key == "phone" || key == "url" {
+			continue
+		}
+		copied[key] = value
+	}
+
+	switch channel {
+	case "email":
+		copied["to"] = recipient
+	case "sms":
+		copied["phone"] = recipient
+	default:
+		copied["url"] = recipient
+	}
+	return copied
+}
@@ -2,73 +2,74 @@ package notification
 
 import (
 	"fmt"
-	"log"
-	"net/http"
-	"net/smtp"
-	"os"
+
+	"deepseek_golang_demo/models"
 )
 
-// Send 发送通知
-func Send(channel string, message string, params map[string]interface{}) error {
-	switch channel {
-	case "email":
-		return sendEmail(message, params)
-	case "sms":
-		return sendSMS(message, params)
-	case "webhook":
-		return sendWebhook(message, params)
-	default:
-		return fmt.Errorf("未知的通知渠道: %s", channel)
-	}
-}
+// EventAnalysisAction 分析结果触发的通知事件
+const EventAnalysisAction = "analysis.action"
 
-// sendEmail 发送邮件通知
-func sendEmail(message string, params map[string]interface{}) error {
-	smtpHost := os.Getenv("SMTP_HOST")
-	smtpPort := os.Getenv("SMTP_PORT")
-	smtpUser := os.Getenv("SMTP_USER")
-	smtpPass := os.Getenv("SMTP_PASS")
+// Notice 通知内容及其关联的记录和分析结果
+type Notice struct {
+	TenantID int64 // 所属租户，决定使用哪个租户的路由规则和接收组
+	Event    string
+	Channel  string
+	Message  string
+	Params   map[string]interface{}
+	Record   *models.DataRecord
+	Analysis *models.AnalysisResult
 
-	to, ok := params["to"].(string)
-	if !ok {
-		return fmt.Errorf("无效的收件人参数")
-	}
+	NotificationID  int64 // 通知记录ID，发送前由 Dispatch 填入，用于生成确认链接
+	GroupID         int64 // 路由到的接收组ID
+	EscalationLevel int   // 升级层级，原始通知为 0
+	NoSuppress      bool  // 不受去重和频率限制，总是立即发送，用于升级通知
+}
 
-	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
-	msg := fmt.Sprintf("To: %s\r\nSubject: 系统通知\r\n\r\n%s", to, message)
+// Channels 支持的通知渠道
+var Channels = []string{"email", "sms", "webhook", "slack", "dingtalk", "feishu", "lark", "wecom"}
 
-	if err := smtp.SendMail(smtpHost+":"+smtpPort, auth, smtpUser, []string{to}, []byte(msg)); err != nil {
-		log.Printf("发送邮件失败: %v", err)
-		return err
+// IsSupportedChannel 判断是否为支持的通知渠道
+func IsSupportedChannel(channel string) bool {
+	for _, c := range Channels {
+		if c == channel {
+			return true
+		}
 	}
-
-	return nil
+	return false
 }
 
-// sendSMS 发送短信通知
-func sendSMS(message string, params map[string]interface{}) error {
-	// 这里需要集成具体的短信服务商API
-	log.Printf("发送短信通知: %s", message)
-	return nil
-}
+// Send 发送通知
+func Send(notice *Notice) error {
+	if notice.Event == "" {
+		notice.Event = EventAnalysisAction
+	}
 
-// sendWebhook 发送Webhook通知
-func sendWebhook(message string, params map[string]interface{}) error {
-	url, ok := params["url"].(string)
-	if !ok {
-		return fmt.Errorf("无效的Webhook URL参数")
+	// 邮件自行渲染主题、纯文本和 HTML 模板，其余渠道先按模板渲染消息正文
+	if notice.Channel == "email" {
+		return sendEmail(notice)
 	}
 
-	resp, err := http.Post(url, "application/json", nil)
+	message, err := renderText(notice, templateText, notice.Message)
 	if err != nil {
-		log.Printf("发送Webhook通知失败: %v", err)
 		return err
 	}
-	defer resp.Body.Close()
+	rendered := *notice
+	rendered.Message = message
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Webhook请求失败，状态码: %d", resp.StatusCode)
+	switch notice.Channel {
+	case "sms":
+		return sendSMS(&rendered)
+	case "webhook":
+		return sendWebhook(&rendered)
+	case "slack":
+		return sendSlack(&rendered)
+	case "dingtalk":
+		return sendDingTalk(&rendered)
+	case "feishu", "lark":
+		return sendFeishu(&rendered)
+	case "wecom":
+		return sendWeCom(&rendered)
+	default:
+		return fmt.Errorf("未知的通知渠道: %s", notice.Channel)
 	}
-
-	return nil
 }
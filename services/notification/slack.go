@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sendSlack 通过 Slack Incoming Webhook 发送通知
+func sendSlack(notice *Notice) error {
+	url, err := webhookURL(notice, "SLACK_WEBHOOK_URL")
+	if err != nil {
+		return err
+	}
+
+	level := severityLevels[noticeSeverity(notice)]
+	title := noticeTitle(notice)
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": title},
+		},
+		{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": notice.Message},
+		},
+	}
+
+	if notice.Analysis != nil {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"fields": []map[string]interface{}{
+				{"type": "mrkdwn", "text": fmt.Sprintf("*严重程度*\n%s", level.Label)},
+				{"type": "mrkdwn", "text": fmt.Sprintf("*置信度*\n%.2f", notice.Analysis.Confidence)},
+			},
+		}, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": "*分析结果*\n" + notice.Analysis.Analysis},
+		})
+	}
+
+	if suggestions := noticeSuggestions(notice); len(suggestions) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": "*建议*\n• " + strings.Join(suggestions, "\n• ")},
+		})
+	}
+
+	var buttons []map[string]interface{}
+	if link := recordLink(notice); link != "" {
+		buttons = append(buttons, map[string]interface{}{
+			"type": "button",
+			"text": map[string]interface{}{"type": "plain_text", "text": "查看记录"},
+			"url":  link,
+		})
+	}
+	if link := AckURL(notice.NotificationID); link != "" {
+		buttons = append(buttons, map[string]interface{}{
+			"type":  "button",
+			"text":  map[string]interface{}{"type": "plain_text", "text": "确认通知"},
+			"url":   link,
+			"style": "primary",
+		})
+	}
+	if len(buttons) > 0 {
+		blocks = append(blocks, map[string]interface{}{"type": "actions", "elements": buttons})
+	}
+
+	payload := map[string]interface{}{
+		"text": title,
+		"attachments": []map[string]interface{}{
+			{"color": level.Hex, "blocks": blocks},
+		},
+	}
+
+	if err := postJSON(url, payload, nil); err != nil {
+		return fmt.Errorf("发送Slack通知失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package notification
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SMSMessage 待发送的短信
+type SMSMessage struct {
+	Phone          string             // E.164 格式手机号，如 +8613800138000
+	Content        string             // 短信正文，已按分段上限截断
+	TemplateCode   string             // 服务商短信模板编号
+	TemplateParams []SMSTemplateParam // 模板参数，按模板中声明的顺序排列
+}
+
+// SMSTemplateParam 短信模板参数
+type SMSTemplateParam struct {
+	Name  string
+	Value string
+}
+
+// templateParamMap 按名称索引的模板参数，用于按名称填充模板的服务商
+func (m *SMSMessage) templateParamMap() map[string]string {
+	params := make(map[string]string, len(m.TemplateParams))
+	for _, param := range m.TemplateParams {
+		params[param.Name] = param.Value
+	}
+	return params
+}
+
+// templateParamValues 按声明顺序排列的模板参数值，用于按位置填充模板的服务商
+func (m *SMSMessage) templateParamValues() []string {
+	values := make([]string, len(m.TemplateParams))
+	for i, param := range m.TemplateParams {
+		values[i] = param.Value
+	}
+	return values
+}
+
+// SMSProvider 短信服务商
+type SMSProvider interface {
+	Name() string
+	Send(msg *SMSMessage) error
+}
+
+const defaultSMSMaxSegments = 5
+
+var (
+	mainlandPhonePattern = regexp.MustCompile(`^1[3-9]\d{9}$`)
+	e164PhonePattern     = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
+)
+
+// NewSMSProvider 根据 SMS_PROVIDER 配置创建短信服务商
+func NewSMSProvider() (SMSProvider, error) {
+	switch provider := os.Getenv("SMS_PROVIDER"); provider {
+	case "aliyun":
+		return newAliyunSMSProvider()
+	case "tencent":
+		return newTencentSMSProvider()
+	case "file":
+		return newFileSMSProvider()
+	case "http":
+		return newHTTPSMSProvider()
+	case "":
+		return nil, fmt.Errorf("未配置短信服务商 (SMS_PROVIDER)")
+	default:
+		return nil, fmt.Errorf("未知的短信服务商: %s", provider)
+	}
+}
+
+// sendSMS 发送短信通知
+func sendSMS(notice *Notice) error {
+	phone, ok := notice.Params["phone"].(string)
+	if !ok {
+		phone, ok = notice.Params["to"].(string)
+	}
+	if !ok {
+		return fmt.Errorf("无效的手机号参数")
+	}
+
+	phone, err := NormalizePhone(phone)
+	if err != nil {
+		return err
+	}
+
+	provider, err := NewSMSProvider()
+	if err != nil {
+		return err
+	}
+
+	content := TruncateSMS(notice.Message, smsMaxSegments())
+	paramName := os.Getenv("SMS_TEMPLATE_PARAM")
+	if paramName == "" {
+		paramName = "content"
+	}
+
+	msg := &SMSMessage{
+		Phone:          phone,
+		Content:        content,
+		TemplateCode:   os.Getenv("SMS_TEMPLATE_CODE"),
+		TemplateParams: []SMSTemplateParam{{Name: paramName, Value: content}},
+	}
+	if err := provider.Send(msg); err != nil {
+		return fmt.Errorf("通过%s发送短信失败: %v", provider.Name(), err)
+	}
+	return nil
+}
+
+// NormalizePhone 校验手机号并转换为 E.164 格式，未带国家码的号码按中国大陆手机号处理
+func NormalizePhone(phone string) (string, error) {
+	normalized := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(phone)
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+
+	switch {
+	case mainlandPhonePattern.MatchString(normalized):
+		return "+86" + normalized, nil
+	case strings.HasPrefix(normalized, "+86"):
+		if !mainlandPhonePattern.MatchString(normalized[3:]) {
+			return "", fmt.Errorf("无效的手机号: %s", phone)
+		}
+		return normalized, nil
+	case e164PhonePattern.MatchString(normalized):
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("无效的手机号: %s", phone)
+	}
+}
+
+// SMSSegments 计算短信正文的计费条数。纯 GSM-7 字符单条 160 字、长短信每条 153 字；
+// 含中文等其他字符时按 UCS-2 计算，单条 70 字、长短信每条 67 字
+func SMSSegments(content string) int {
+	single, multi := smsSegmentLimits(content)
+	length := utf8.RuneCountInString(content)
+	if length <= single {
+		return 1
+	}
+	return (length + multi - 1) / multi
+}
+
+// TruncateSMS 将短信正文截断到不超过 maxSegments 条，被截断时以省略号结尾
+func TruncateSMS(content string, maxSegments int) string {
+	if maxSegments <= 0 || SMSSegments(content) <= maxSegments {
+		return content
+	}
+
+	single, multi := smsSegmentLimits(content)
+	limit := multi * maxSegments
+	if maxSegments == 1 {
+		limit = single
+	}
+
+	// 纯 GSM-7 正文使用 ASCII 省略号，避免整条短信被迫改用 UCS-2 编码
+	ellipsis := "..."
+	if single == 70 {
+		ellipsis = "…"
+	}
+
+	runes := []rune(content)
+	return string(runes[:limit-utf8.RuneCountInString(ellipsis)]) + ellipsis
+}
+
+// smsSegmentLimits 返回单条短信和长短信每条的字数上限
+func smsSegmentLimits(content string) (int, int) {
+	for _, r := range content {
+		if r > 0x7F {
+			return 70, 67
+		}
+	}
+	return 160, 153
+}
+
+// smsMaxSegments 读取 SMS_MAX_SEGMENTS 配置的最大短信条数
+func smsMaxSegments() int {
+	if value, err := strconv.Atoi(os.Getenv("SMS_MAX_SEGMENTS")); err == nil && value > 0 {
+		return value
+	}
+	return defaultSMSMaxSegments
+}
@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const aliyunSMSEndpoint = "https://dysmsapi.aliyuncs.com/"
+
+// aliyunSMSProvider 阿里云短信服务
+type aliyunSMSProvider struct {
+	accessKeyID     string
+	accessKeySecret string
+	signName        string
+	regionID        string
+	endpoint        string
+}
+
+// aliyunSMSResponse 阿里云 SendSms 接口响应
+type aliyunSMSResponse struct {
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+	RequestID string `json:"RequestId"`
+	BizID     string `json:"BizId"`
+}
+
+func newAliyunSMSProvider() (*aliyunSMSProvider, error) {
+	p := &aliyunSMSProvider{
+		accessKeyID:     os.Getenv("ALIYUN_ACCESS_KEY_ID"),
+		accessKeySecret: os.Getenv("ALIYUN_ACCESS_KEY_SECRET"),
+		signName:        os.Getenv("SMS_SIGN_NAME"),
+		regionID:        os.Getenv("ALIYUN_REGION_ID"),
+		endpoint:        os.Getenv("ALIYUN_SMS_ENDPOINT"),
+	}
+	if p.accessKeyID == "" || p.accessKeySecret == "" || p.signName == "" {
+		return nil, fmt.Errorf("ALIYUN_ACCESS_KEY_ID、ALIYUN_ACCESS_KEY_SECRET 和 SMS_SIGN_NAME 必须配置")
+	}
+	if p.regionID == "" {
+		p.regionID = "cn-hangzhou"
+	}
+	if p.endpoint == "" {
+		p.endpoint = aliyunSMSEndpoint
+	}
+	return p, nil
+}
+
+func (p *aliyunSMSProvider) Name() string {
+	return "aliyun"
+}
+
+func (p *aliyunSMSProvider) Send(msg *SMSMessage) error {
+	if msg.TemplateCode == "" {
+		return fmt.Errorf("阿里云短信需要配置模板编号 (SMS_TEMPLATE_CODE)")
+	}
+
+	templateParam, err := json.Marshal(msg.templateParamMap())
+	if err != nil {
+		return fmt.Errorf("序列化模板参数失败: %v", err)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("生成签名随机数失败: %v", err)
+	}
+
+	params := map[string]string{
+		"AccessKeyId":      p.accessKeyID,
+		"Action":           "SendSms",
+		"Format":           "JSON",
+		"PhoneNumbers":     aliyunPhoneNumber(msg.Phone),
+		"RegionId":         p.regionID,
+		"SignName":         p.signName,
+		"SignatureMethod":  "HMAC-SHA1",
+		"SignatureNonce":   hex.EncodeToString(nonce),
+		"SignatureVersion": "1.0",
+		"TemplateCode":     msg.TemplateCode,
+		"TemplateParam":    string(templateParam),
+		"Timestamp":        time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		"Version":          "2017-05-25",
+	}
+
+	query := aliyunCanonicalQuery(params)
+	signature := signAliyun(p.accessKeySecret, "GET", query)
+
+	client := &http.Client{Timeout: webhookTimeout()}
+	resp, err := client.Get(p.endpoint + "?Signature=" + aliyunPercentEncode(signature) + "&" + query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result aliyunSMSResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("解析阿里云短信响应失败: %v, 响应: %s", err, string(body))
+	}
+	if result.Code != "OK" {
+		return fmt.Errorf("阿里云短信发送失败: %s %s (RequestId: %s)", result.Code, result.Message, result.RequestID)
+	}
+	return nil
+}
+
+// aliyunPhoneNumber 阿里云国内短信使用不带国家码的号码，国际短信使用 00 前缀
+func aliyunPhoneNumber(phone string) string {
+	if strings.HasPrefix(phone, "+86") {
+		return phone[3:]
+	}
+	return "00" + strings.TrimPrefix(phone, "+")
+}
+
+// aliyunCanonicalQuery 按参数名排序并编码，生成待签名的规范化请求字符串
+func aliyunCanonicalQuery(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, aliyunPercentEncode(key)+"="+aliyunPercentEncode(params[key]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// signAliyun 按阿里云 RPC 签名机制计算 HMAC-SHA1 签名
+func signAliyun(secret string, method string, canonicalQuery string) string {
+	stringToSign := method + "&" + aliyunPercentEncode("/") + "&" + aliyunPercentEncode(canonicalQuery)
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// aliyunPercentEncode 阿里云签名要求的 RFC 3986 编码
+func aliyunPercentEncode(value string) string {
+	encoded := url.QueryEscape(value)
+	return strings.NewReplacer("+", "%20", "*", "%2A", "%7E", "~").Replace(encoded)
+}
@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// fileSMSProvider 将短信追加写入本地文件（每行一条 JSON），用于本地开发和测试
+type fileSMSProvider struct {
+	path string
+}
+
+// httpSMSProvider 将短信以 JSON 形式 POST 到指定地址，用于对接测试桩服务
+type httpSMSProvider struct {
+	url string
+}
+
+// stubSMSRecord 测试桩记录的短信内容
+type stubSMSRecord struct {
+	Phone          string            `json:"phone"`
+	Content        string            `json:"content"`
+	Segments       int               `json:"segments"`
+	TemplateCode   string            `json:"template_code,omitempty"`
+	TemplateParams map[string]string `json:"template_params,omitempty"`
+	SentAt         time.Time         `json:"sent_at"`
+}
+
+func newStubSMSRecord(msg *SMSMessage) stubSMSRecord {
+	return stubSMSRecord{
+		Phone:          msg.Phone,
+		Content:        msg.Content,
+		Segments:       SMSSegments(msg.Content),
+		TemplateCode:   msg.TemplateCode,
+		TemplateParams: msg.templateParamMap(),
+		SentAt:         time.Now(),
+	}
+}
+
+func newFileSMSProvider() (*fileSMSProvider, error) {
+	path := os.Getenv("SMS_STUB_FILE")
+	if path == "" {
+		path = "sms_outbox.log"
+	}
+	return &fileSMSProvider{path: path}, nil
+}
+
+func (p *fileSMSProvider) Name() string {
+	return "file"
+}
+
+func (p *fileSMSProvider) Send(msg *SMSMessage) error {
+	line, err := json.Marshal(newStubSMSRecord(msg))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("打开短信输出文件失败: %v", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+func newHTTPSMSProvider() (*httpSMSProvider, error) {
+	url := os.Getenv("SMS_STUB_URL")
+	if url == "" {
+		return nil, fmt.Errorf("SMS_STUB_URL 必须配置")
+	}
+	return &httpSMSProvider{url: url}, nil
+}
+
+func (p *httpSMSProvider) Name() string {
+	return "http"
+}
+
+func (p *httpSMSProvider) Send(msg *SMSMessage) error {
+	return postJSON(p.url, newStubSMSRecord(msg), nil)
+}
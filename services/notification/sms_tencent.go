@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	tencentSMSHost    = "sms.tencentcloudapi.com"
+	tencentSMSService = "sms"
+	tencentSMSVersion = "2021-01-11"
+)
+
+// tencentSMSProvider 腾讯云短信服务
+type tencentSMSProvider struct {
+	secretID  string
+	secretKey string
+	appID     string
+	signName  string
+	region    string
+}
+
+// tencentSMSResponse 腾讯云 SendSms 接口响应
+type tencentSMSResponse struct {
+	Response struct {
+		SendStatusSet []struct {
+			Code        string `json:"Code"`
+			Message     string `json:"Message"`
+			PhoneNumber string `json:"PhoneNumber"`
+		} `json:"SendStatusSet"`
+		Error *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error"`
+		RequestID string `json:"RequestId"`
+	} `json:"Response"`
+}
+
+func newTencentSMSProvider() (*tencentSMSProvider, error) {
+	p := &tencentSMSProvider{
+		secretID:  os.Getenv("TENCENT_SECRET_ID"),
+		secretKey: os.Getenv("TENCENT_SECRET_KEY"),
+		appID:     os.Getenv("TENCENT_SMS_APP_ID"),
+		signName:  os.Getenv("SMS_SIGN_NAME"),
+		region:    os.Getenv("TENCENT_REGION"),
+	}
+	if p.secretID == "" || p.secretKey == "" || p.appID == "" || p.signName == "" {
+		return nil, fmt.Errorf("TENCENT_SECRET_ID、TENCENT_SECRET_KEY、TENCENT_SMS_APP_ID 和 SMS_SIGN_NAME 必须配置")
+	}
+	if p.region == "" {
+		p.region = "ap-guangzhou"
+	}
+	return p, nil
+}
+
+func (p *tencentSMSProvider) Name() string {
+	return "tencent"
+}
+
+func (p *tencentSMSProvider) Send(msg *SMSMessage) error {
+	if msg.TemplateCode == "" {
+		return fmt.Errorf("腾讯云短信需要配置模板ID (SMS_TEMPLATE_CODE)")
+	}
+
+	payload, err := p.payload(msg)
+	if err != nil {
+		return fmt.Errorf("序列化请求体失败: %v", err)
+	}
+
+	now := time.Now()
+	req, err := http.NewRequest("POST", "https://"+tencentSMSHost, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Host", tencentSMSHost)
+	req.Header.Set("X-TC-Action", "SendSms")
+	req.Header.Set("X-TC-Version", tencentSMSVersion)
+	req.Header.Set("X-TC-Region", p.region)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set("Authorization", signTencentTC3(p.secretID, p.secretKey, payload, now))
+
+	client := &http.Client{Timeout: webhookTimeout()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result tencentSMSResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("解析腾讯云短信响应失败: %v, 响应: %s", err, string(body))
+	}
+	if result.Response.Error != nil {
+		return fmt.Errorf("腾讯云短信发送失败: %s %s (RequestId: %s)",
+			result.Response.Error.Code, result.Response.Error.Message, result.Response.RequestID)
+	}
+	for _, status := range result.Response.SendStatusSet {
+		if status.Code != "Ok" {
+			return fmt.Errorf("腾讯云短信发送失败: %s %s (%s)", status.Code, status.Message, status.PhoneNumber)
+		}
+	}
+	return nil
+}
+
+// payload SendSms 请求体。腾讯云模板参数按位置填充，TemplateParamSet 保持模板中声明的顺序
+func (p *tencentSMSProvider) payload(msg *SMSMessage) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"PhoneNumberSet":   []string{msg.Phone},
+		"SmsSdkAppId":      p.appID,
+		"SignName":         p.signName,
+		"TemplateId":       msg.TemplateCode,
+		"TemplateParamSet": msg.templateParamValues(),
+	})
+}
+
+// signTencentTC3 按 TC3-HMAC-SHA256 签名方法生成 Authorization 请求头
+func signTencentTC3(secretID string, secretKey string, payload []byte, now time.Time) string {
+	date := now.UTC().Format("2006-01-02")
+	canonicalRequest := "POST\n/\n\n" +
+		"content-type:application/json; charset=utf-8\nhost:" + tencentSMSHost + "\n\n" +
+		"content-type;host\n" + sha256Hex(payload)
+	credentialScope := date + "/" + tencentSMSService + "/tc3_request"
+	stringToSign := "TC3-HMAC-SHA256\n" + strconv.FormatInt(now.Unix(), 10) + "\n" +
+		credentialScope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, tencentSMSService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	return fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		secretID, credentialScope, signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
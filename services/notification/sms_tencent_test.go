@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestTencentSMSPayloadKeepsTemplateParamOrder(t *testing.T) {
+	p := &tencentSMSProvider{appID: "1400000000", signName: "示例"}
+	msg := &SMSMessage{Phone: "+8613800138000", TemplateCode: "123456"}
+	want := []string{}
+	for i := 0; i < 10; i++ {
+		value := fmt.Sprintf("value-%d", i)
+		msg.TemplateParams = append(msg.TemplateParams, SMSTemplateParam{Name: fmt.Sprintf("p%d", i), Value: value})
+		want = append(want, value)
+	}
+
+	// 多次生成，避免按 map 遍历时偶然得到正确顺序
+	for i := 0; i < 20; i++ {
+		payload, err := p.payload(msg)
+		if err != nil {
+			t.Fatalf("payload: %v", err)
+		}
+		var body struct {
+			TemplateID       string   `json:"TemplateId"`
+			TemplateParamSet []string `json:"TemplateParamSet"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			t.Fatalf("decoding payload: %v", err)
+		}
+		if fmt.Sprint(body.TemplateParamSet) != fmt.Sprint(want) {
+			t.Fatalf("TemplateParamSet = %v, want %v", body.TemplateParamSet, want)
+		}
+		if body.TemplateID != "123456" {
+			t.Fatalf("TemplateId = %q", body.TemplateID)
+		}
+	}
+}
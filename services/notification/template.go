@@ -0,0 +1,132 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"deepseek_golang_demo/models"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// 模板种类
+const (
+	templateSubject = "subject"
+	templateText    = "text"
+	templateHTML    = "html"
+)
+
+// TemplateData 通知模板可引用的数据
+type TemplateData struct {
+	Event         string
+	Channel       string
+	Title         string
+	Message       string
+	Record        *models.DataRecord
+	Analysis      *models.AnalysisResult
+	Suggestions   []string
+	Confidence    float64
+	Severity      int
+	SeverityLabel string
+	SeverityColor string
+	RecordLink    string
+	AckLink       string
+	Params        map[string]interface{}
+}
+
+// newTemplateData 根据通知内容构建模板数据
+func newTemplateData(notice *Notice) *TemplateData {
+	severity := noticeSeverity(notice)
+	level := severityLevels[severity]
+	data := &TemplateData{
+		Event:         notice.Event,
+		Channel:       notice.Channel,
+		Title:         noticeTitle(notice),
+		Message:       notice.Message,
+		Record:        notice.Record,
+		Analysis:      notice.Analysis,
+		Suggestions:   noticeSuggestions(notice),
+		Severity:      severity,
+		SeverityLabel: level.Label,
+		SeverityColor: level.Hex,
+		RecordLink:    recordLink(notice),
+		AckLink:       AckURL(notice.NotificationID),
+		Params:        notice.Params,
+	}
+	if notice.Analysis != nil {
+		data.Confidence = notice.Analysis.Confidence
+	}
+	return data
+}
+
+// templateCandidates 返回按优先级排列的模板文件名：
+// <channel>.<event>.<kind>.tmpl > <channel>.<kind>.tmpl > default.<event>.<kind>.tmpl > default.<kind>.tmpl
+func templateCandidates(channel string, event string, kind string) []string {
+	return []string{
+		fmt.Sprintf("%s.%s.%s.tmpl", channel, event, kind),
+		fmt.Sprintf("%s.%s.tmpl", channel, kind),
+		fmt.Sprintf("default.%s.%s.tmpl", event, kind),
+		fmt.Sprintf("default.%s.tmpl", kind),
+	}
+}
+
+// loadTemplate 查找模板内容，NOTIFY_TEMPLATE_DIR 中的模板优先于内置模板
+func loadTemplate(channel string, event string, kind string) (string, string, bool) {
+	dir := os.Getenv("NOTIFY_TEMPLATE_DIR")
+	for _, name := range templateCandidates(channel, event, kind) {
+		if dir != "" {
+			if content, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+				return name, string(content), true
+			}
+		}
+		if content, err := defaultTemplates.ReadFile("templates/" + name); err == nil {
+			return name, string(content), true
+		}
+	}
+	return "", "", false
+}
+
+// renderText 使用 text/template 渲染模板，找不到模板时返回 fallback
+func renderText(notice *Notice, kind string, fallback string) (string, error) {
+	name, content, ok := loadTemplate(notice.Channel, notice.Event, kind)
+	if !ok {
+		return fallback, nil
+	}
+
+	tmpl, err := texttemplate.New(name).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("解析通知模板 %s 失败: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newTemplateData(notice)); err != nil {
+		return "", fmt.Errorf("渲染通知模板 %s 失败: %v", name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// renderHTML 使用 html/template 渲染模板，找不到模板时返回空字符串
+func renderHTML(notice *Notice) (string, error) {
+	name, content, ok := loadTemplate(notice.Channel, notice.Event, templateHTML)
+	if !ok {
+		return "", nil
+	}
+
+	tmpl, err := htmltemplate.New(name).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("解析通知模板 %s 失败: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newTemplateData(notice)); err != nil {
+		return "", fmt.Errorf("渲染通知模板 %s 失败: %v", name, err)
+	}
+	return buf.String(), nil
+}
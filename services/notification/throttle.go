@@ -0,0 +1,158 @@
+package notification
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+// RateLimit 通知频率限制：每个渠道接收人在 Window 内最多发送 Count 条
+type RateLimit struct {
+	Count  int
+	Window time.Duration
+}
+
+var (
+	numberPattern     = regexp.MustCompile(`\d+`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// ParseRateLimit 解析形如 "10/1h" 的频率限制配置
+func ParseRateLimit(value string) (RateLimit, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return RateLimit{}, fmt.Errorf("无效的频率限制配置: %s", value)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || count <= 0 {
+		return RateLimit{}, fmt.Errorf("无效的频率限制次数: %s", value)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return RateLimit{}, fmt.Errorf("无效的频率限制时间窗口: %s", value)
+	}
+	return RateLimit{Count: count, Window: window}, nil
+}
+
+// channelRateLimit 读取渠道的频率限制，NOTIFY_RATE_LIMIT_<CHANNEL> 优先于 NOTIFY_RATE_LIMIT
+func channelRateLimit(channel string) (RateLimit, bool) {
+	value := os.Getenv("NOTIFY_RATE_LIMIT_" + strings.ToUpper(channel))
+	if value == "" {
+		value = os.Getenv("NOTIFY_RATE_LIMIT")
+	}
+	if value == "" {
+		return RateLimit{}, false
+	}
+
+	limit, err := ParseRateLimit(value)
+	if err != nil {
+		log.Printf("忽略通知频率限制配置: %v", err)
+		return RateLimit{}, false
+	}
+	return limit, true
+}
+
+// dedupWindow 读取 NOTIFY_DEDUP_WINDOW 配置的去重时间窗口，未配置时不去重
+func dedupWindow() time.Duration {
+	value := os.Getenv("NOTIFY_DEDUP_WINDOW")
+	if value == "" {
+		return 0
+	}
+
+	window, err := time.ParseDuration(value)
+	if err != nil || window < 0 {
+		log.Printf("忽略无效的NOTIFY_DEDUP_WINDOW配置: %s", value)
+		return 0
+	}
+	return window
+}
+
+// noticeRecipient 返回通知的接收人
+func noticeRecipient(notice *Notice) string {
+	var key string
+	switch notice.Channel {
+	case "email":
+		key = "to"
+	case "sms":
+		key = "phone"
+		if _, ok := notice.Params[key]; !ok {
+			key = "to"
+		}
+	default:
+		key = "url"
+	}
+	recipient, _ := notice.Params[key].(string)
+	return recipient
+}
+
+// NormalizeMessage 规范化通知内容用于去重：统一小写、合并空白，并将数字替换为 #，
+// 使仅有计数、时间或ID不同的消息视为重复
+func NormalizeMessage(message string) string {
+	normalized := strings.ToLower(strings.TrimSpace(message))
+	normalized = numberPattern.ReplaceAllString(normalized, "#")
+	return whitespacePattern.ReplaceAllString(normalized, " ")
+}
+
+// dedupKey 根据记录类型、标签、渠道、接收人和规范化后的内容计算去重键
+func dedupKey(db *sql.DB, notice *Notice, recipient string) (string, error) {
+	var recordType string
+	var tagNames []string
+	if notice.Record != nil {
+		recordType = notice.Record.Type
+		tags, err := models.GetTagsByRecordID(db, notice.TenantID, notice.Record.ID)
+		if err != nil {
+			return "", fmt.Errorf("获取记录标签失败: %v", err)
+		}
+		for _, tag := range tags {
+			tagNames = append(tagNames, tag.TagName)
+		}
+		sort.Strings(tagNames)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		recordType,
+		strings.Join(tagNames, ","),
+		notice.Channel,
+		recipient,
+		NormalizeMessage(notice.Message),
+	}, "|")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// shouldSuppress 判断通知是否因重复或超过频率限制而不发送，返回原因
+func shouldSuppress(db *sql.DB, notice *Notice, recipient string, key string) (string, error) {
+	now := time.Now()
+
+	if window := dedupWindow(); window > 0 {
+		duplicated, err := models.HasRecentNotification(db, notice.TenantID, key, now.Add(-window))
+		if err != nil {
+			return "", err
+		}
+		if duplicated {
+			return fmt.Sprintf("%s 内已发送过相同通知", window), nil
+		}
+	}
+
+	if limit, ok := channelRateLimit(notice.Channel); ok {
+		count, err := models.CountSentNotifications(db, notice.TenantID, notice.Channel, recipient, now.Add(-limit.Window))
+		if err != nil {
+			return "", err
+		}
+		if count >= limit.Count {
+			return fmt.Sprintf("超过频率限制 %d/%s", limit.Count, limit.Window), nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+// WebhookPayloadVersion Webhook 请求体的版本号，字段发生不兼容变更时递增
+const WebhookPayloadVersion = "1"
+
+const (
+	// WebhookTimestampHeader 签名时间戳请求头，接收方应拒绝时间偏差过大的请求以防重放
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	// WebhookSignatureHeader 签名请求头，值为 sha256=HMAC-SHA256(secret, timestamp + "." + body) 的十六进制
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookEventHeader 事件类型请求头
+	WebhookEventHeader = "X-Webhook-Event"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookPayload Webhook 通知的请求体
+type WebhookPayload struct {
+	Version        string                 `json:"version"`
+	Event          string                 `json:"event"`
+	NotificationID int64                  `json:"notification_id,omitempty"`
+	AckURL         string                 `json:"ack_url,omitempty"`
+	Record         *models.DataRecord     `json:"record,omitempty"`
+	Analysis       *models.AnalysisResult `json:"analysis,omitempty"`
+	Params         map[string]interface{} `json:"params,omitempty"`
+	Message        string                 `json:"message"`
+	Timestamp      time.Time              `json:"timestamp"`
+}
+
+// sendWebhook 发送Webhook通知
+func sendWebhook(notice *Notice) error {
+	url, ok := notice.Params["url"].(string)
+	if !ok {
+		return fmt.Errorf("无效的Webhook URL参数")
+	}
+
+	now := time.Now()
+	body, err := json.Marshal(WebhookPayload{
+		Version:        WebhookPayloadVersion,
+		Event:          notice.Event,
+		NotificationID: notice.NotificationID,
+		AckURL:         AckURL(notice.NotificationID),
+		Record:         notice.Record,
+		Analysis:       notice.Analysis,
+		Params:         notice.Params,
+		Message:        notice.Message,
+		Timestamp:      now,
+	})
+	if err != nil {
+		return fmt.Errorf("序列化Webhook请求体失败: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("创建Webhook请求失败: %v", err)
+	}
+
+	headers, err := webhookHeaders()
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, notice.Event)
+
+	if secret := os.Getenv("WEBHOOK_SECRET"); secret != "" {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(secret, timestamp, body))
+	}
+
+	client := &http.Client{Timeout: webhookTimeout()}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("发送Webhook通知失败: %v", err)
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SignWebhook 计算 Webhook 请求签名，接收方可用同一函数校验
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookHeaders 读取 WEBHOOK_HEADERS 中配置的附加请求头（JSON 对象）
+func webhookHeaders() (map[string]string, error) {
+	raw := os.Getenv("WEBHOOK_HEADERS")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(raw), &headers); err != nil {
+		return nil, fmt.Errorf("无效的WEBHOOK_HEADERS配置: %v", err)
+	}
+	return headers, nil
+}
+
+// webhookTimeout 读取 WEBHOOK_TIMEOUT 配置的请求超时
+func webhookTimeout() time.Duration {
+	raw := os.Getenv("WEBHOOK_TIMEOUT")
+	if raw == "" {
+		return defaultWebhookTimeout
+	}
+
+	timeout, err := time.ParseDuration(raw)
+	if err != nil || timeout <= 0 {
+		log.Printf("无效的WEBHOOK_TIMEOUT配置 %q，使用默认值 %s", raw, defaultWebhookTimeout)
+		return defaultWebhookTimeout
+	}
+	return timeout
+}
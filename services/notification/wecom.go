@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"fmt"
+)
+
+// weComResponse 企业微信群机器人接口响应
+type weComResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// sendWeCom 通过企业微信群机器人发送 markdown 通知
+func sendWeCom(notice *Notice) error {
+	webhook, err := webhookURL(notice, "WECOM_WEBHOOK_URL")
+	if err != nil {
+		return err
+	}
+
+	level := severityLevels[noticeSeverity(notice)]
+	content := fmt.Sprintf("### <font color=\"%s\">%s</font>\n%s", level.WeCom, noticeTitle(notice), markdownBody(notice))
+	if link := recordLink(notice); link != "" {
+		content += fmt.Sprintf("\n\n[查看记录](%s)", link)
+	}
+
+	var resp weComResponse
+	if err := postJSON(webhook, map[string]interface{}{
+		"msgtype":  "markdown",
+		"markdown": map[string]interface{}{"content": content},
+	}, &resp); err != nil {
+		return fmt.Errorf("发送企业微信通知失败: %v", err)
+	}
+	if resp.ErrCode != 0 {
+		return fmt.Errorf("发送企业微信通知失败: %d %s", resp.ErrCode, resp.ErrMsg)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package ratelimit
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"deepseek_golang_demo/models"
+)
+
+// 限流类别：普通接口和调用 DeepSeek 的分析接口使用不同的限额，
+// auth 类别在认证之前按客户端 IP 计数，限制未认证请求和猜测 API Key 的请求
+const (
+	ClassAPI     = "api"
+	ClassAnalyze = "analyze"
+	ClassAuth    = "auth"
+)
+
+// 未配置时的默认限额
+const (
+	DefaultAPIRate     = "300/1m"
+	DefaultAnalyzeRate = "30/1m"
+	DefaultAuthRate    = "600/1m"
+)
+
+// sweepInterval 清理空闲令牌桶的间隔
+const sweepInterval = 10 * time.Minute
+
+// Rate 令牌桶限额：桶容量为 Count，每 Window 补满一次
+type Rate struct {
+	Count  int
+	Window time.Duration
+}
+
+// refill 每秒补充的令牌数
+func (r Rate) refill() float64 {
+	return float64(r.Count) / r.Window.Seconds()
+}
+
+// ParseRate 解析形如 "300/1m" 的限额配置
+func ParseRate(value string) (Rate, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return Rate{}, fmt.Errorf("invalid rate: %s", value)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || count <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate count: %s", value)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate window: %s", value)
+	}
+	return Rate{Count: count, Window: window}, nil
+}
+
+// Store 保存令牌桶状态
+type Store interface {
+	// Take 从 key 对应的桶中取出一个令牌，令牌不足时返回需要等待的时间
+	Take(key string, rate Rate, now time.Time) (bool, time.Duration, error)
+}
+
+// Limiter 按调用方和类别限流
+type Limiter struct {
+	store Store
+	rates map[string]Rate // 未配置的类别不限流
+}
+
+// NewFromEnv 根据环境变量创建限流器：
+// RATE_LIMIT_API、RATE_LIMIT_ANALYZE、RATE_LIMIT_AUTH 配置各类别的限额（off 表示不限制），
+// RATE_LIMIT_STORE=mysql 时令牌桶保存在 MySQL 中，多个实例共享限额，默认保存在内存中
+func NewFromEnv(db *sql.DB) *Limiter {
+	limiter := &Limiter{rates: map[string]Rate{}}
+	for class, def := range map[string]string{
+		ClassAPI: DefaultAPIRate, ClassAnalyze: DefaultAnalyzeRate, ClassAuth: DefaultAuthRate,
+	} {
+		name := "RATE_LIMIT_" + strings.ToUpper(class)
+		value := os.Getenv(name)
+		if value == "" {
+			value = def
+		}
+		if value == "off" {
+			continue
+		}
+		rate, err := ParseRate(value)
+		if err != nil {
+			log.Printf("忽略无效的%s配置: %v", name, err)
+			rate, _ = ParseRate(def)
+		}
+		limiter.rates[class] = rate
+	}
+
+	switch store := os.Getenv("RATE_LIMIT_STORE"); store {
+	case "", "memory":
+		limiter.store = NewMemoryStore()
+	case "mysql":
+		limiter.store = NewMySQLStore(db)
+	default:
+		log.Printf("忽略无效的RATE_LIMIT_STORE配置: %s", store)
+		limiter.store = NewMemoryStore()
+	}
+	return limiter
+}
+
+// Allow 判断调用方 key 在类别 class 下的请求是否放行，拒绝时返回建议的重试等待时间。
+// 存储出错时放行请求，避免限流故障导致服务不可用
+func (l *Limiter) Allow(class, key string) (bool, time.Duration) {
+	rate, ok := l.rates[class]
+	if !ok {
+		return true, 0
+	}
+	allowed, retryAfter, err := l.store.Take(class+":"+key, rate, time.Now())
+	if err != nil {
+		log.Printf("限流检查失败 (%s): %v", key, err)
+		return true, 0
+	}
+	return allowed, retryAfter
+}
+
+// bucket 内存中的令牌桶
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	window    time.Duration
+}
+
+// MemoryStore 保存在进程内存中的令牌桶，仅对单个实例生效
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryStore 创建内存令牌桶存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, lastSweep: time.Now()}
+}
+
+func (m *MemoryStore) Take(key string, rate Rate, now time.Time) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 超过一个窗口未使用的桶已经补满，删除后行为不变
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for k, b := range m.buckets {
+			if now.Sub(b.updatedAt) > b.window {
+				delete(m.buckets, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	capacity := float64(rate.Count)
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updatedAt: now}
+		m.buckets[key] = b
+	}
+	b.window = rate.Window
+	if elapsed := now.Sub(b.updatedAt).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate.refill())
+	}
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate.refill() * float64(time.Second)), nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+// MySQLStore 保存在 MySQL 中的令牌桶，多个实例共享同一限额
+type MySQLStore struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+	maxWindow time.Duration // 使用过的最长窗口，超过该时间未使用的桶已经补满
+}
+
+// NewMySQLStore 创建 MySQL 令牌桶存储
+func NewMySQLStore(db *sql.DB) *MySQLStore {
+	return &MySQLStore{db: db, lastSweep: time.Now()}
+}
+
+func (m *MySQLStore) Take(key string, rate Rate, now time.Time) (bool, time.Duration, error) {
+	m.mu.Lock()
+	m.maxWindow = max(m.maxWindow, rate.Window)
+	idle := max(m.maxWindow, sweepInterval)
+	sweep := now.Sub(m.lastSweep) >= sweepInterval
+	if sweep {
+		m.lastSweep = now
+	}
+	m.mu.Unlock()
+	if sweep {
+		go func() {
+			if _, err := models.DeleteIdleRateLimitBuckets(m.db, now.Add(-idle)); err != nil {
+				log.Printf("清理限流令牌桶失败: %v", err)
+			}
+		}()
+	}
+
+	return models.TakeRateLimitToken(m.db, key, float64(rate.Count), rate.refill(), now)
+}
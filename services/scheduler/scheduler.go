@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"deepseek_golang_demo/models"
+
+	"github.com/robfig/cron/v3"
+)
+
+// leaderLockName 调度器使用的 MySQL 命名锁，多个实例中只有持有锁的实例执行定时规则
+const leaderLockName = "deepseek_demo.scheduler"
+
+// leaderLockKey 调度器在 PostgreSQL 中使用的 advisory 锁的键，作用与 leaderLockName 相同
+const leaderLockKey int64 = 0x64656d6f
+
+// leaderLock 获取调度锁和确认仍持有调度锁的 SQL，结果为 1 表示成功
+type leaderLock struct {
+	acquire string
+	held    string
+	arg     interface{}
+}
+
+var leaderLocks = map[models.Dialect]leaderLock{
+	models.DialectMySQL: {
+		acquire: "SELECT GET_LOCK(?, 0)",
+		held:    "SELECT IS_USED_LOCK(?) = CONNECTION_ID()",
+		arg:     leaderLockName,
+	},
+	models.DialectPostgres: {
+		acquire: "SELECT CASE WHEN pg_try_advisory_lock(?) THEN 1 ELSE 0 END",
+		held: `SELECT EXISTS (SELECT 1 FROM pg_locks
+			WHERE locktype = 'advisory' AND pid = pg_backend_pid() AND objid = ? AND granted)`,
+		arg: leaderLockKey,
+	},
+}
+
+// DefaultMaxRecords 规则未设置上限时单次最多分析的记录数
+const DefaultMaxRecords = 1000
+
+// StartBatchFunc 以 createdBy 的身份为租户的记录创建批量分析任务并在后台执行
+type StartBatchFunc func(tenantID int64, recordIDs []int64, createdBy string) (*models.AnalysisBatch, error)
+
+// Scheduler 定时分析调度器
+type Scheduler struct {
+	db         *sql.DB
+	startBatch StartBatchFunc
+	maxRecords int
+
+	// conn 持有命名锁的连接，MySQL 的命名锁和 PostgreSQL 的 advisory 锁都属于会话，连接断开时自动释放
+	conn *sql.Conn
+}
+
+// New 创建调度器，maxRecords 为规则未设置上限时单次最多分析的记录数
+func New(db *sql.DB, startBatch StartBatchFunc, maxRecords int) *Scheduler {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxRecords
+	}
+	return &Scheduler{db: db, startBatch: startBatch, maxRecords: maxRecords}
+}
+
+// ParseCron 解析标准 5 段 cron 表达式，也支持 @every 15m、@hourly 等写法和 CRON_TZ= 前缀
+func ParseCron(expr string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	return schedule, nil
+}
+
+// NextRun 计算 cron 表达式在 after 之后的下一次执行时间
+func NextRun(expr string, after time.Time) (time.Time, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after), nil
+}
+
+// Run 每隔 interval 检查一次到期的规则，阻塞直到进程退出
+func (s *Scheduler) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if s.isLeader() {
+			if err := s.RunDue(time.Now()); err != nil {
+				log.Printf("执行定时分析规则失败: %v", err)
+			}
+		}
+		<-ticker.C
+	}
+}
+
+// isLeader 尝试获取或确认仍持有调度锁。SQLite 文件数据库只供单个实例使用，不需要调度锁
+func (s *Scheduler) isLeader() bool {
+	lock, ok := leaderLocks[models.DialectOf(s.db)]
+	if !ok {
+		return true
+	}
+	ctx := context.Background()
+
+	if s.conn != nil {
+		var held sql.NullBool
+		err := s.conn.QueryRowContext(ctx, lock.held, lock.arg).Scan(&held)
+		if err == nil && held.Valid && held.Bool {
+			return true
+		}
+		log.Printf("调度器失去调度锁: %v", err)
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		log.Printf("获取调度锁连接失败: %v", err)
+		return false
+	}
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, lock.acquire, lock.arg).Scan(&acquired); err != nil {
+		log.Printf("获取调度锁失败: %v", err)
+		conn.Close()
+		return false
+	}
+	if acquired.Int64 != 1 {
+		conn.Close()
+		return false
+	}
+
+	log.Printf("调度器获得调度锁，开始执行定时分析规则")
+	s.conn = conn
+	return true
+}
+
+// RunDue 执行所有到期的规则
+func (s *Scheduler) RunDue(now time.Time) error {
+	schedules, err := models.GetDueSchedules(s.db, now)
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if err := s.runSchedule(&schedule, now); err != nil {
+			log.Printf("执行定时分析规则失败 (ID: %d, 名称: %s): %v", schedule.ID, schedule.Name, err)
+		}
+	}
+	return nil
+}
+
+// runSchedule 为规则选择的记录创建批量分析任务。上一次创建的任务仍在执行时跳过本次
+func (s *Scheduler) runSchedule(schedule *models.Schedule, now time.Time) error {
+	next, err := NextRun(schedule.Cron, now)
+	if err != nil {
+		return err
+	}
+
+	if schedule.LastBatchID != 0 {
+		batch, err := models.GetAnalysisBatch(s.db, schedule.TenantID, schedule.LastBatchID)
+		if err != nil {
+			return err
+		}
+		if batch != nil && (batch.Status == models.BatchStatusPending || batch.Status == models.BatchStatusRunning) {
+			log.Printf("定时分析规则的上一次任务仍在执行，跳过本次 (ID: %d, 任务: %d)", schedule.ID, batch.ID)
+			// new 模式不更新执行时间，下次执行时补上本次的新记录
+			runAt := now
+			if schedule.Mode == models.ScheduleModeNew && schedule.LastRunAt != nil {
+				runAt = *schedule.LastRunAt
+			}
+			return models.MarkScheduleRun(s.db, schedule.ID, runAt, next, 0)
+		}
+	}
+
+	filter := schedule.Filter.RecordFilter(schedule.TenantID)
+	switch schedule.Mode {
+	case models.ScheduleModeNew:
+		since := schedule.CreatedAt
+		if schedule.LastRunAt != nil {
+			since = *schedule.LastRunAt
+		}
+		filter.CreatedAfter = &since
+		filter.CreatedBefore = &now
+	case models.ScheduleModeUnanalyzed:
+		analyzed := false
+		filter.Analyzed = &analyzed
+	}
+
+	limit := schedule.MaxRecords
+	if limit <= 0 {
+		limit = s.maxRecords
+	}
+	recordIDs, err := models.SelectRecordIDs(s.db, filter, limit)
+	if err != nil {
+		return err
+	}
+
+	var batchID int64
+	if len(recordIDs) > 0 {
+		batch, err := s.startBatch(schedule.TenantID, recordIDs, "schedule:"+schedule.Name)
+		if err != nil {
+			return err
+		}
+		batchID = batch.ID
+		log.Printf("定时分析规则创建批量分析任务 (ID: %d, 任务: %d, 记录数: %d)", schedule.ID, batch.ID, len(recordIDs))
+	}
+	return models.MarkScheduleRun(s.db, schedule.ID, now, next, batchID)
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"deepseek_golang_demo/models"
+)
+
+func TestIsLeaderSQLite(t *testing.T) {
+	db, err := models.NewDB("file:" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	defer db.Close()
+
+	// SQLite 不使用调度锁，每个调度器都执行定时规则
+	first, second := New(db, nil, 0), New(db, nil, 0)
+	if !first.isLeader() || !second.isLeader() {
+		t.Errorf("schedulers on SQLite are not leaders")
+	}
+	if first.conn != nil || second.conn != nil {
+		t.Errorf("schedulers on SQLite hold a lock connection")
+	}
+}
+
+// TestIsLeaderPostgres 在 POSTGRES_TEST_DSN 指定的数据库上检查 advisory 锁选主，未设置时跳过
+func TestIsLeaderPostgres(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+	db, err := models.NewDB(dsn)
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	defer db.Close()
+
+	first, second := New(db, nil, 0), New(db, nil, 0)
+	defer func() {
+		for _, s := range []*Scheduler{first, second} {
+			if s.conn != nil {
+				s.conn.Close()
+			}
+		}
+	}()
+
+	if !first.isLeader() {
+		t.Fatalf("first scheduler did not acquire the lock")
+	}
+	// 再次检查时通过 pg_locks 确认仍持有锁
+	conn := first.conn
+	if !first.isLeader() || first.conn != conn {
+		t.Fatalf("first scheduler lost the lock")
+	}
+	if second.isLeader() {
+		t.Fatalf("second scheduler acquired a lock held by the first")
+	}
+
+	// 持有锁的连接断开后锁自动释放，由另一个调度器接管
+	first.conn.Close()
+	first.conn = nil
+	if !second.isLeader() {
+		t.Fatalf("second scheduler did not take over the released lock")
+	}
+	if first.isLeader() {
+		t.Errorf("first scheduler acquired a lock held by the second")
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"deepseek_golang_demo/models"
+)
+
+// promptFlags 收集重复的 -prompt type=file 参数
+type promptFlags map[string]string
+
+func (p promptFlags) String() string {
+	return fmt.Sprint(map[string]string(p))
+}
+
+func (p promptFlags) Set(value string) error {
+	recordType, path, ok := strings.Cut(value, "=")
+	if !ok || recordType == "" {
+		return fmt.Errorf("expected type=file, got %q", value)
+	}
+	// 文件为空时删除该类型的模板覆盖
+	if path == "" {
+		p[recordType] = ""
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	p[recordType] = string(content)
+	return nil
+}
+
+// runTenant 管理租户：
+//
+//	go run . tenant create -slug team-a -name "Team A" [-budget 1000000] [-deepseek-key sk-...]
+//	go run . tenant set -slug team-a [-name ...] [-budget ...] [-deepseek-key ...] [-prompt log=prompts/log.txt]
+//	go run . tenant list
+func runTenant(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: tenant create|set|list [flags]")
+	}
+
+	prompts := promptFlags{}
+	flags := flag.NewFlagSet("tenant "+args[0], flag.ContinueOnError)
+	slug := flags.String("slug", "", "租户标识，导入和创建 API Key 时使用")
+	name := flags.String("name", "", "租户名称")
+	budget := flags.Int64("budget", -1, "每月 DeepSeek token 预算，0 表示不限制")
+	deepseekKey := flags.String("deepseek-key", "", "租户自己的 DeepSeek API Key，为空时使用 DEEPSEEK_API_KEY")
+	flags.Var(prompts, "prompt", "提示词模板覆盖 type=file，type 为数据类型或 system，可重复；file 为空时删除覆盖")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "create":
+		if *slug == "" || *name == "" {
+			return fmt.Errorf("-slug and -name are required")
+		}
+		tenant := &models.Tenant{
+			Slug:            *slug,
+			Name:            *name,
+			DeepSeekAPIKey:  *deepseekKey,
+			PromptTemplates: map[string]string{},
+		}
+		if *budget > 0 {
+			tenant.MonthlyTokenBudget = *budget
+		}
+		for recordType, template := range prompts {
+			if template != "" {
+				tenant.PromptTemplates[recordType] = template
+			}
+		}
+		if err := models.CreateTenant(db, tenant); err != nil {
+			return err
+		}
+		fmt.Printf("created tenant %q (id: %d)\n", tenant.Slug, tenant.ID)
+	case "set":
+		tenant, err := lookupTenant(db, *slug)
+		if err != nil {
+			return err
+		}
+		flags.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "name":
+				tenant.Name = *name
+			case "budget":
+				tenant.MonthlyTokenBudget = *budget
+			case "deepseek-key":
+				tenant.DeepSeekAPIKey = *deepseekKey
+			}
+		})
+		if tenant.MonthlyTokenBudget < 0 {
+			return fmt.Errorf("-budget must not be negative")
+		}
+		for recordType, template := range prompts {
+			if template == "" {
+				delete(tenant.PromptTemplates, recordType)
+			} else {
+				tenant.PromptTemplates[recordType] = template
+			}
+		}
+		if err := models.UpdateTenant(db, tenant); err != nil {
+			return err
+		}
+		fmt.Printf("updated tenant %q\n", tenant.Slug)
+	case "list":
+		tenants, err := models.ListTenants(db)
+		if err != nil {
+			return err
+		}
+		for _, tenant := range tenants {
+			usage, err := models.GetTenantUsage(db, tenant.ID)
+			if err != nil {
+				return err
+			}
+			budget := "unlimited"
+			if tenant.MonthlyTokenBudget > 0 {
+				budget = fmt.Sprint(tenant.MonthlyTokenBudget)
+			}
+			fmt.Printf("%-4d %-16s %-24s tokens %d/%s (%s)  own key: %v\n",
+				tenant.ID, tenant.Slug, tenant.Name, usage.Tokens, budget, usage.Month, tenant.HasDeepSeekAPIKey)
+		}
+	default:
+		return fmt.Errorf("unknown tenant command: %s", args[0])
+	}
+	return nil
+}
+
+// lookupTenant 根据标识获取租户，不存在时返回错误
+func lookupTenant(db *sql.DB, slug string) (*models.Tenant, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("tenant slug is required")
+	}
+	tenant, err := models.GetTenantBySlug(db, slug)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, fmt.Errorf("tenant %q not found", slug)
+	}
+	return tenant, nil
+}